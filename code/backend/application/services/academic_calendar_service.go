@@ -0,0 +1,298 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidAcademicCalendar   = errors.New("invalid academic calendar")
+	ErrAcademicTermNotFound      = errors.New("academic term not found")
+	ErrAcademicExclusionNotFound = errors.New("academic exclusion not found")
+	ErrAcademicCalendarForbidden = errors.New("only admins can change the academic calendar")
+)
+
+type AcademicCalendarService interface {
+	GetTerms() ([]entities.AcademicTerm, error)
+	GetTermByID(id uuid.UUID) (entities.AcademicTerm, error)
+	GetTermForDate(date time.Time) (entities.AcademicTerm, error)
+	CreateNewTerm(userId uuid.UUID, Term entities.AcademicTerm) (entities.AcademicTerm, error)
+	UpdateTerm(userId uuid.UUID, Term entities.AcademicTerm) error
+	DeleteTerm(id uuid.UUID, userId uuid.UUID) error
+	GetExclusions(start time.Time, end time.Time) ([]entities.AcademicExclusion, error)
+	CreateNewExclusion(userId uuid.UUID, Exclusion entities.AcademicExclusion) (entities.AcademicExclusion, error)
+	UpdateExclusion(userId uuid.UUID, Exclusion entities.AcademicExclusion) error
+	DeleteExclusion(id uuid.UUID, userId uuid.UUID) error
+	WeeklyOccurrences(start time.Time, until time.Time) ([]time.Time, error)
+}
+
+type academicCalendarService struct {
+	repo     repositories.AcademicCalendarRepository
+	userRepo repositories.UserRepository
+}
+
+func NewAcademicCalendarService() AcademicCalendarService {
+	return &academicCalendarService{
+		repo:     repositories.NewAcademicCalendarRepository(),
+		userRepo: repositories.NewUserRepository(),
+	}
+}
+
+func (s *academicCalendarService) GetTerms() ([]entities.AcademicTerm, error) {
+	return s.repo.GetAllTerms()
+}
+
+func (s *academicCalendarService) GetTermByID(id uuid.UUID) (entities.AcademicTerm, error) {
+	term, err := s.repo.FindTerm(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.AcademicTerm{}, ErrAcademicTermNotFound
+	}
+	if err != nil {
+		return entities.AcademicTerm{}, err
+	}
+
+	return term, nil
+}
+
+// GetTermForDate finds the term the calendar date of date falls in.
+func (s *academicCalendarService) GetTermForDate(date time.Time) (entities.AcademicTerm, error) {
+	term, err := s.repo.FindTermByDate(date)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.AcademicTerm{}, ErrAcademicTermNotFound
+	}
+	if err != nil {
+		return entities.AcademicTerm{}, err
+	}
+
+	return term, nil
+}
+
+func (s *academicCalendarService) CreateNewTerm(userId uuid.UUID, Term entities.AcademicTerm) (entities.AcademicTerm, error) {
+	if err := s.requireAdmin(userId); err != nil {
+		return entities.AcademicTerm{}, err
+	}
+
+	Term.Id = uuid.New()
+	if err := s.validateTerm(&Term); err != nil {
+		return entities.AcademicTerm{}, err
+	}
+
+	if err := s.repo.CreateNewTerm(Term); err != nil {
+		return entities.AcademicTerm{}, err
+	}
+
+	return Term, nil
+}
+
+func (s *academicCalendarService) UpdateTerm(userId uuid.UUID, Term entities.AcademicTerm) error {
+	if err := s.requireAdmin(userId); err != nil {
+		return err
+	}
+
+	if _, err := s.GetTermByID(Term.Id); err != nil {
+		return err
+	}
+
+	if err := s.validateTerm(&Term); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateTerm(Term)
+}
+
+// DeleteTerm also deletes the term's breaks. Schedules already created are
+// left as they are.
+func (s *academicCalendarService) DeleteTerm(id uuid.UUID, userId uuid.UUID) error {
+	if err := s.requireAdmin(userId); err != nil {
+		return err
+	}
+
+	if _, err := s.GetTermByID(id); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteTerm(id)
+}
+
+// GetExclusions returns the breaks and holidays sharing a day with [start, end].
+func (s *academicCalendarService) GetExclusions(start time.Time, end time.Time) ([]entities.AcademicExclusion, error) {
+	return s.repo.GetExclusionsBetween(start, end)
+}
+
+func (s *academicCalendarService) CreateNewExclusion(userId uuid.UUID, Exclusion entities.AcademicExclusion) (entities.AcademicExclusion, error) {
+	if err := s.requireAdmin(userId); err != nil {
+		return entities.AcademicExclusion{}, err
+	}
+
+	Exclusion.Id = uuid.New()
+	if err := s.validateExclusion(&Exclusion); err != nil {
+		return entities.AcademicExclusion{}, err
+	}
+
+	if err := s.repo.CreateNewExclusion(Exclusion); err != nil {
+		return entities.AcademicExclusion{}, err
+	}
+
+	return Exclusion, nil
+}
+
+func (s *academicCalendarService) UpdateExclusion(userId uuid.UUID, Exclusion entities.AcademicExclusion) error {
+	if err := s.requireAdmin(userId); err != nil {
+		return err
+	}
+
+	if _, err := s.getExclusionByID(Exclusion.Id); err != nil {
+		return err
+	}
+
+	if err := s.validateExclusion(&Exclusion); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateExclusion(Exclusion)
+}
+
+func (s *academicCalendarService) DeleteExclusion(id uuid.UUID, userId uuid.UUID) error {
+	if err := s.requireAdmin(userId); err != nil {
+		return err
+	}
+
+	if _, err := s.getExclusionByID(id); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteExclusion(id)
+}
+
+// WeeklyOccurrences expands a weekly recurrence from start through the
+// calendar date of until, leaving out occurrences that start on a break or
+// holiday.
+func (s *academicCalendarService) WeeklyOccurrences(start time.Time, until time.Time) ([]time.Time, error) {
+	exclusions, err := s.repo.GetExclusionsBetween(start, until)
+	if err != nil {
+		return nil, err
+	}
+
+	lastDay := time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, start.Location()).AddDate(0, 0, 1)
+
+	occurrences := make([]time.Time, 0)
+	for curr := start; curr.Before(lastDay); curr = curr.AddDate(0, 0, 7) {
+		excluded := false
+		for _, exclusion := range exclusions {
+			if exclusion.Covers(curr) {
+				excluded = true
+				break
+			}
+		}
+
+		if !excluded {
+			occurrences = append(occurrences, curr)
+		}
+	}
+
+	return occurrences, nil
+}
+
+func (s *academicCalendarService) getExclusionByID(id uuid.UUID) (entities.AcademicExclusion, error) {
+	exclusion, err := s.repo.FindExclusion(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.AcademicExclusion{}, ErrAcademicExclusionNotFound
+	}
+	if err != nil {
+		return entities.AcademicExclusion{}, err
+	}
+
+	return exclusion, nil
+}
+
+func (s *academicCalendarService) requireAdmin(userId uuid.UUID) error {
+	user, err := s.userRepo.FindUser(userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrAcademicCalendarForbidden
+	}
+	if err != nil {
+		return err
+	}
+	if user.Role != entities.RoleAdmin {
+		return ErrAcademicCalendarForbidden
+	}
+
+	return nil
+}
+
+// validateTerm trims the term and rejects one that overlaps another term, so
+// every date belongs to at most one term.
+func (s *academicCalendarService) validateTerm(Term *entities.AcademicTerm) error {
+	Term.Name = strings.TrimSpace(Term.Name)
+	Term.StartDate = calendarDate(Term.StartDate)
+	Term.EndDate = calendarDate(Term.EndDate)
+
+	if Term.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidAcademicCalendar)
+	}
+	if Term.StartDate.IsZero() || Term.EndDate.Before(Term.StartDate) {
+		return fmt.Errorf("%w: end date must not be before start date", ErrInvalidAcademicCalendar)
+	}
+
+	overlapping, err := s.repo.GetOverlappingTerms(Term.StartDate, Term.EndDate)
+	if err != nil {
+		return err
+	}
+	for _, other := range overlapping {
+		if other.Id != Term.Id {
+			return fmt.Errorf("%w: overlaps %s", ErrInvalidAcademicCalendar, other.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateExclusion requires breaks to lie within their term.
+func (s *academicCalendarService) validateExclusion(Exclusion *entities.AcademicExclusion) error {
+	Exclusion.Name = strings.TrimSpace(Exclusion.Name)
+	Exclusion.StartDate = calendarDate(Exclusion.StartDate)
+	Exclusion.EndDate = calendarDate(Exclusion.EndDate)
+
+	if Exclusion.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidAcademicCalendar)
+	}
+	if Exclusion.Kind != entities.AcademicExclusionBreak && Exclusion.Kind != entities.AcademicExclusionHoliday {
+		return fmt.Errorf("%w: kind must be %s or %s", ErrInvalidAcademicCalendar,
+			entities.AcademicExclusionBreak, entities.AcademicExclusionHoliday)
+	}
+	if Exclusion.StartDate.IsZero() || Exclusion.EndDate.Before(Exclusion.StartDate) {
+		return fmt.Errorf("%w: end date must not be before start date", ErrInvalidAcademicCalendar)
+	}
+
+	if Exclusion.TermId == nil {
+		if Exclusion.Kind == entities.AcademicExclusionBreak {
+			return fmt.Errorf("%w: a break needs a term", ErrInvalidAcademicCalendar)
+		}
+		return nil
+	}
+
+	term, err := s.GetTermByID(*Exclusion.TermId)
+	if err != nil {
+		return err
+	}
+	if !term.Contains(Exclusion.StartDate) || !term.Contains(Exclusion.EndDate) {
+		return fmt.Errorf("%w: dates must be within %s", ErrInvalidAcademicCalendar, term.Name)
+	}
+
+	return nil
+}
+
+func calendarDate(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
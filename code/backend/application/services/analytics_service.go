@@ -0,0 +1,241 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidAnalyticsQuery = errors.New("invalid analytics query")
+)
+
+const (
+	maxAnalyticsRangeDays = 366
+	uncategorized         = "Uncategorized"
+)
+
+type AnalyticsService interface {
+	GetTimeAnalytics(query entities.AnalyticsQuery) (entities.TimeAnalytics, error)
+}
+
+type analyticsService struct {
+	repo            repositories.ScheduleRepository
+	categoryService CategoryService
+}
+
+func NewAnalyticsService() AnalyticsService {
+	return &analyticsService{
+		repo:            repositories.NewScheduleRepository(),
+		categoryService: NewCategoryService(),
+	}
+}
+
+type analyticsEvent struct {
+	category string
+	start    time.Time
+	end      time.Time
+}
+
+// GetTimeAnalytics totals the hours of the user's owned and accepted schedules.
+// Recurring schedules are stored one row per occurrence, so every occurrence in
+// the range counts. Events are clipped to the range, and time where events
+// overlap is split evenly between them rather than counted twice. All-day
+// events mark days rather than hours spent and are left out.
+func (s *analyticsService) GetTimeAnalytics(query entities.AnalyticsQuery) (entities.TimeAnalytics, error) {
+	if query.UserId == uuid.Nil || !query.End.After(query.Start) ||
+		query.End.Sub(query.Start) > maxAnalyticsRangeDays*24*time.Hour {
+		return entities.TimeAnalytics{}, ErrInvalidAnalyticsQuery
+	}
+
+	schedules, err := s.repo.GetBusySchedulesByUser(query.UserId, query.Start, query.End)
+	if err != nil {
+		return entities.TimeAnalytics{}, err
+	}
+
+	events := make([]analyticsEvent, 0, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.IsAllDay {
+			continue
+		}
+
+		category := schedule.Category
+		if category == "" {
+			category = uncategorized
+		}
+		events = append(events, analyticsEvent{
+			category: category,
+			start:    maxTime(schedule.StartTime, query.Start),
+			end:      minTime(schedule.EndTime, query.End),
+		})
+	}
+
+	totals := newAnalyticsTotals(query.Start, query.End)
+	for _, segment := range splitOverlaps(events) {
+		share := 1 / float64(len(segment.categories))
+
+		// Walk hour by hour so the weekday, hour and week buckets stay exact.
+		for t := segment.start; t.Before(segment.end); {
+			next := minTime(t.Truncate(time.Hour).Add(time.Hour), segment.end)
+			hours := next.Sub(t).Hours() * share
+			for _, category := range segment.categories {
+				totals.add(category, t, hours)
+			}
+			t = next
+		}
+	}
+
+	colors := map[string]string{}
+	if categories, err := s.categoryService.GetCategoriesByUser(query.UserId); err == nil {
+		for _, category := range categories {
+			colors[category.Name] = category.Color
+		}
+	}
+
+	return totals.result(query.Start, query.End, colors), nil
+}
+
+type analyticsSegment struct {
+	start      time.Time
+	end        time.Time
+	categories []string // one entry per event active in the segment
+}
+
+// splitOverlaps cuts the timeline at every event boundary and lists which events
+// are active in each resulting piece.
+func splitOverlaps(events []analyticsEvent) []analyticsSegment {
+	boundaries := make([]time.Time, 0, 2*len(events))
+	for _, event := range events {
+		if event.end.After(event.start) {
+			boundaries = append(boundaries, event.start, event.end)
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool {
+		return boundaries[i].Before(boundaries[j])
+	})
+
+	segments := make([]analyticsSegment, 0)
+	for i := 0; i+1 < len(boundaries); i++ {
+		start, end := boundaries[i], boundaries[i+1]
+		if !end.After(start) {
+			continue
+		}
+
+		categories := make([]string, 0)
+		for _, event := range events {
+			if !event.start.After(start) && !event.end.Before(end) {
+				categories = append(categories, event.category)
+			}
+		}
+		if len(categories) > 0 {
+			segments = append(segments, analyticsSegment{start: start, end: end, categories: categories})
+		}
+	}
+
+	return segments
+}
+
+type analyticsTotals struct {
+	total      float64
+	byCategory map[string]float64
+	byWeekday  [7]float64
+	byHour     [24]float64
+	weeks      []entities.WeekHours
+}
+
+func newAnalyticsTotals(start time.Time, end time.Time) *analyticsTotals {
+	totals := &analyticsTotals{byCategory: map[string]float64{}}
+	for week := weekStart(start, time.UTC); week.Before(end); week = week.AddDate(0, 0, 7) {
+		totals.weeks = append(totals.weeks, entities.WeekHours{WeekStart: week, ByCategory: map[string]float64{}})
+	}
+
+	return totals
+}
+
+func (t *analyticsTotals) add(category string, at time.Time, hours float64) {
+	at = at.UTC()
+	t.total += hours
+	t.byCategory[category] += hours
+	t.byWeekday[at.Weekday()] += hours
+	t.byHour[at.Hour()] += hours
+
+	week := weekStart(at, time.UTC)
+	for i := range t.weeks {
+		if t.weeks[i].WeekStart.Equal(week) {
+			t.weeks[i].TotalHours += hours
+			t.weeks[i].ByCategory[category] += hours
+			return
+		}
+	}
+}
+
+func (t *analyticsTotals) result(start time.Time, end time.Time, colors map[string]string) entities.TimeAnalytics {
+	analytics := entities.TimeAnalytics{
+		Start:      start,
+		End:        end,
+		TotalHours: roundHours(t.total),
+		ByCategory: make([]entities.CategoryHours, 0, len(t.byCategory)),
+		ByWeekday:  make([]entities.WeekdayHours, 0, 7),
+		ByHour:     make([]float64, 24),
+		Weeks:      t.weeks,
+	}
+
+	for category, hours := range t.byCategory {
+		analytics.ByCategory = append(analytics.ByCategory, entities.CategoryHours{Category: category, Color: colors[category], Hours: roundHours(hours)})
+	}
+	sort.Slice(analytics.ByCategory, func(i, j int) bool {
+		if analytics.ByCategory[i].Hours != analytics.ByCategory[j].Hours {
+			return analytics.ByCategory[i].Hours > analytics.ByCategory[j].Hours
+		}
+		return analytics.ByCategory[i].Category < analytics.ByCategory[j].Category
+	})
+
+	// Monday first, as in the frontend calendar.
+	for i := 1; i <= 7; i++ {
+		weekday := time.Weekday(i % 7)
+		analytics.ByWeekday = append(analytics.ByWeekday, entities.WeekdayHours{Weekday: weekday.String(), Hours: roundHours(t.byWeekday[weekday])})
+	}
+
+	for hour, hours := range t.byHour {
+		analytics.ByHour[hour] = roundHours(hours)
+	}
+
+	for i := range analytics.Weeks {
+		analytics.Weeks[i].TotalHours = roundHours(analytics.Weeks[i].TotalHours)
+		for category, hours := range analytics.Weeks[i].ByCategory {
+			analytics.Weeks[i].ByCategory[category] = roundHours(hours)
+		}
+	}
+
+	return analytics
+}
+
+// weekStart returns the Monday midnight in loc of the week containing t.
+func weekStart(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	return midnight.AddDate(0, 0, -((int(midnight.Weekday()) + 6) % 7))
+}
+
+func minTime(a time.Time, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/storage"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidAttachment  = errors.New("invalid attachment")
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+)
+
+const MaxAttachmentSize = 10 << 20
+
+// attachmentContentTypes lists the accepted file extensions. The stored content
+// type comes from this table rather than from the uploading client.
+var attachmentContentTypes = map[string]string{
+	".pdf":  "application/pdf",
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".txt":  "text/plain; charset=utf-8",
+	".md":   "text/markdown; charset=utf-8",
+	".doc":  "application/msword",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".ppt":  "application/vnd.ms-powerpoint",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".xls":  "application/vnd.ms-excel",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".zip":  "application/zip",
+}
+
+type AttachmentService interface {
+	UploadAttachment(scheduleId uuid.UUID, userId uuid.UUID, fileName string, size int64, content io.Reader) (entities.Attachment, error)
+	GetAttachmentsBySchedule(scheduleId uuid.UUID, userId uuid.UUID) ([]entities.Attachment, error)
+	OpenAttachment(id uuid.UUID, userId uuid.UUID) (entities.Attachment, io.ReadCloser, error)
+	DeleteAttachment(id uuid.UUID, userId uuid.UUID) error
+}
+
+type attachmentService struct {
+	repo         repositories.AttachmentRepository
+	scheduleRepo repositories.ScheduleRepository
+	blobs        storage.BlobStore
+}
+
+func NewAttachmentService() AttachmentService {
+	return &attachmentService{
+		repo:         repositories.NewAttachmentRepository(),
+		scheduleRepo: repositories.NewScheduleRepository(),
+		blobs:        storage.NewLocalBlobStore(),
+	}
+}
+
+func (s *attachmentService) UploadAttachment(scheduleId uuid.UUID, userId uuid.UUID, fileName string, size int64, content io.Reader) (entities.Attachment, error) {
+	if _, err := s.authorize(scheduleId, userId); err != nil {
+		return entities.Attachment{}, err
+	}
+
+	fileName = filepath.Base(strings.TrimSpace(fileName))
+	contentType, ok := attachmentContentTypes[strings.ToLower(filepath.Ext(fileName))]
+	if !ok || fileName == "." || fileName == string(filepath.Separator) {
+		return entities.Attachment{}, fmt.Errorf("%w: unsupported file type", ErrInvalidAttachment)
+	}
+	if size > MaxAttachmentSize {
+		return entities.Attachment{}, ErrAttachmentTooLarge
+	}
+
+	attachment := entities.Attachment{
+		Id:          uuid.New(),
+		ScheduleId:  scheduleId,
+		UserId:      userId,
+		FileName:    fileName,
+		ContentType: contentType,
+		CreatedAt:   time.Now(),
+	}
+	attachment.StorageKey = "attachments/" + scheduleId.String() + "/" + attachment.Id.String()
+
+	// Read one byte past the limit so a client that lied about the size is caught.
+	written, err := s.blobs.Put(attachment.StorageKey, io.LimitReader(content, MaxAttachmentSize+1))
+	if err != nil {
+		return entities.Attachment{}, err
+	}
+	if written > MaxAttachmentSize {
+		s.blobs.Delete(attachment.StorageKey)
+		return entities.Attachment{}, ErrAttachmentTooLarge
+	}
+	attachment.Size = written
+
+	if err := s.repo.CreateNewAttachment(attachment); err != nil {
+		s.blobs.Delete(attachment.StorageKey)
+		return entities.Attachment{}, err
+	}
+
+	return attachment, nil
+}
+
+func (s *attachmentService) GetAttachmentsBySchedule(scheduleId uuid.UUID, userId uuid.UUID) ([]entities.Attachment, error) {
+	if _, err := s.authorize(scheduleId, userId); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.repo.GetAttachmentsBySchedule(scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (s *attachmentService) OpenAttachment(id uuid.UUID, userId uuid.UUID) (entities.Attachment, io.ReadCloser, error) {
+	attachment, err := s.find(id)
+	if err != nil {
+		return entities.Attachment{}, nil, err
+	}
+
+	if _, err := s.authorize(attachment.ScheduleId, userId); err != nil {
+		return entities.Attachment{}, nil, err
+	}
+
+	content, err := s.blobs.Get(attachment.StorageKey)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return entities.Attachment{}, nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return entities.Attachment{}, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+// DeleteAttachment is allowed for the uploader and for the schedule owner.
+func (s *attachmentService) DeleteAttachment(id uuid.UUID, userId uuid.UUID) error {
+	attachment, err := s.find(id)
+	if err != nil {
+		return err
+	}
+
+	schedule, err := s.authorize(attachment.ScheduleId, userId)
+	if err != nil {
+		return err
+	}
+	if attachment.UserId != userId && schedule.UserId != userId {
+		return ErrScheduleForbidden
+	}
+
+	if err := s.repo.DeleteAttachment(id); err != nil {
+		return err
+	}
+
+	return s.blobs.Delete(attachment.StorageKey)
+}
+
+func (s *attachmentService) find(id uuid.UUID) (entities.Attachment, error) {
+	attachment, err := s.repo.FindAttachment(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.Attachment{}, ErrAttachmentNotFound
+	}
+
+	return attachment, err
+}
+
+// authorize lets the schedule owner and its accepted participants through.
+func (s *attachmentService) authorize(scheduleId uuid.UUID, userId uuid.UUID) (entities.Schedule, error) {
+	schedule, err := s.scheduleRepo.FindSchedule(scheduleId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.Schedule{}, ErrScheduleForbidden
+	}
+	if err != nil {
+		return entities.Schedule{}, err
+	}
+
+	if schedule.UserId == userId {
+		return schedule, nil
+	}
+
+	participants, err := s.scheduleRepo.GetAllAcceptedSchedulesBySchedule(scheduleId)
+	if err != nil {
+		return entities.Schedule{}, err
+	}
+	for _, participant := range participants {
+		if participant.UserId == userId {
+			return schedule, nil
+		}
+	}
+
+	return entities.Schedule{}, ErrScheduleForbidden
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+)
+
+const bulkMaxSchedules = 500
+
+var (
+	ErrInvalidBulkRequest = errors.New("invalid bulk request")
+
+	errBulkRollback = errors.New("bulk operation rolled back")
+)
+
+type BulkScheduleService interface {
+	Apply(request entities.BulkScheduleRequest) (entities.BulkScheduleResult, error)
+}
+
+type bulkScheduleService struct {
+	repo      repositories.ScheduleRepository
+	schedules *scheduleService
+}
+
+func NewBulkScheduleService() BulkScheduleService {
+	schedules := newScheduleService()
+	return &bulkScheduleService{
+		repo:      schedules.repo,
+		schedules: schedules,
+	}
+}
+
+type bulkChange struct {
+	before entities.Schedule
+	after  entities.Schedule
+}
+
+// Apply runs one operation over the selected schedules in a single transaction.
+// Each schedule is checked and written in turn so that later items see the room
+// bookings of earlier ones; if any item fails, or on a dry run, everything is
+// rolled back and the per-item results show what would have happened.
+func (s *bulkScheduleService) Apply(request entities.BulkScheduleRequest) (entities.BulkScheduleResult, error) {
+	if err := validateBulkRequest(request); err != nil {
+		return entities.BulkScheduleResult{}, err
+	}
+
+	ids, err := s.selectSchedules(request)
+	if err != nil {
+		return entities.BulkScheduleResult{}, err
+	}
+	if len(ids) > bulkMaxSchedules {
+		return entities.BulkScheduleResult{}, fmt.Errorf("%w: at most %d schedules at once", ErrInvalidBulkRequest, bulkMaxSchedules)
+	}
+
+	result := entities.BulkScheduleResult{
+		DryRun: request.DryRun,
+		Items:  make([]entities.BulkScheduleItemResult, 0, len(ids)),
+	}
+	changes := make([]bulkChange, 0, len(ids))
+
+	err = s.repo.WithTransaction(func(tx repositories.ScheduleRepository) error {
+		failed := false
+		for _, id := range ids {
+			item := entities.BulkScheduleItemResult{ScheduleId: id, Status: "ok"}
+
+			change, err := s.applyOne(tx, request, id)
+			if err != nil {
+				item.Status = "failed"
+				item.Error = err.Error()
+				failed = true
+			} else {
+				item.Before = &change.before
+				if request.Operation != entities.BulkDelete {
+					item.After = &change.after
+				}
+				changes = append(changes, change)
+			}
+
+			result.Items = append(result.Items, item)
+		}
+
+		if failed || request.DryRun {
+			return errBulkRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBulkRollback) {
+		return entities.BulkScheduleResult{}, err
+	}
+
+	result.Applied = err == nil
+	if result.Applied {
+		for _, change := range changes {
+			if request.Operation == entities.BulkDelete {
+				s.schedules.deleted(change.before, request.UserId)
+				continue
+			}
+			s.schedules.revisionService.Record(entities.RevisionUpdated, request.UserId, &change.before, &change.after)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *bulkScheduleService) applyOne(tx repositories.ScheduleRepository, request entities.BulkScheduleRequest, id uuid.UUID) (bulkChange, error) {
+	permission := SchedulePermissionEdit
+	if request.Operation == entities.BulkDelete {
+		permission = SchedulePermissionDelete
+	}
+
+	before, err := s.schedules.AuthorizeSchedule(id, request.UserId, permission)
+	if err != nil {
+		return bulkChange{}, err
+	}
+
+	after := before
+	switch request.Operation {
+	case entities.BulkDelete:
+		return bulkChange{before: before}, tx.DeleteSchedule(id)
+	case entities.BulkShift:
+		if before.IsAllDay && request.Shift%(24*time.Hour) != 0 {
+			return bulkChange{}, fmt.Errorf("%w: all-day schedules can only move by whole days", ErrInvalidBulkRequest)
+		}
+		after.StartTime = before.StartTime.Add(request.Shift)
+		after.EndTime = before.EndTime.Add(request.Shift)
+	case entities.BulkChangeLocation:
+		after.Location = request.Location
+		after.RoomId = request.RoomId
+	case entities.BulkChangeCategory:
+		after.CategoryId = request.CategoryId
+	}
+
+	if err := s.schedules.prepare(&after); err != nil {
+		return bulkChange{}, err
+	}
+	if err := tx.UpdateSchedule(after); err != nil {
+		return bulkChange{}, err
+	}
+
+	after.Version++
+	return bulkChange{before: before, after: after}, nil
+}
+
+// selectSchedules returns the requested IDs without duplicates or, without IDs,
+// the user's own schedules matching the filter in start order.
+func (s *bulkScheduleService) selectSchedules(request entities.BulkScheduleRequest) ([]uuid.UUID, error) {
+	if len(request.ScheduleIds) > 0 {
+		seen := make(map[uuid.UUID]bool, len(request.ScheduleIds))
+		ids := make([]uuid.UUID, 0, len(request.ScheduleIds))
+		for _, id := range request.ScheduleIds {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+
+		return ids, nil
+	}
+
+	schedules, err := s.repo.GetAllSchedules(request.UserId.String())
+	if err != nil {
+		return nil, err
+	}
+
+	filter := request.Filter
+	matched := make([]entities.Schedule, 0)
+	for _, schedule := range schedules {
+		if !schedule.StartTime.Before(filter.End) || !schedule.EndTime.After(filter.Start) {
+			continue
+		}
+		if filter.CategoryId != nil && (schedule.CategoryId == nil || *schedule.CategoryId != *filter.CategoryId) {
+			continue
+		}
+		matched = append(matched, schedule)
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].StartTime.Before(matched[j].StartTime)
+	})
+
+	ids := make([]uuid.UUID, 0, len(matched))
+	for _, schedule := range matched {
+		ids = append(ids, schedule.Id)
+	}
+
+	return ids, nil
+}
+
+func validateBulkRequest(request entities.BulkScheduleRequest) error {
+	if request.UserId == uuid.Nil {
+		return fmt.Errorf("%w: userId is required", ErrInvalidBulkRequest)
+	}
+	if len(request.ScheduleIds) == 0 && !request.Filter.End.After(request.Filter.Start) {
+		return fmt.Errorf("%w: select schedules by id or by a date range", ErrInvalidBulkRequest)
+	}
+
+	switch request.Operation {
+	case entities.BulkDelete:
+	case entities.BulkShift:
+		if request.Shift == 0 {
+			return fmt.Errorf("%w: shift must not be zero", ErrInvalidBulkRequest)
+		}
+	case entities.BulkChangeLocation:
+		if request.Location == "" && request.RoomId == nil {
+			return fmt.Errorf("%w: location or roomId is required", ErrInvalidBulkRequest)
+		}
+	case entities.BulkChangeCategory:
+		if request.CategoryId == nil {
+			return fmt.Errorf("%w: categoryId is required", ErrInvalidBulkRequest)
+		}
+	default:
+		return fmt.Errorf("%w: unknown operation %q", ErrInvalidBulkRequest, request.Operation)
+	}
+
+	return nil
+}
@@ -0,0 +1,367 @@
+package services
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCalDAVNotFound           = errors.New("calendar object not found")
+	ErrCalDAVPreconditionFailed = errors.New("calendar object precondition failed")
+)
+
+const calDAVProductID = "-//RUsman//Calendar//EN"
+
+// calDAVStamp is the DTSTAMP of every exported event. Schedules keep no
+// modification time, and a fixed stamp in the past keeps the ETag tied to the
+// event's content.
+var calDAVStamp = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// CalDAVService exposes a user's own schedules as calendar objects. Every write
+// goes through ScheduleService so CalDAV clients and the web app share one code path.
+type CalDAVService interface {
+	GetCTag(userId uuid.UUID) (string, error)
+	GetObjects(userId uuid.UUID, start *time.Time, end *time.Time) ([]entities.CalDAVObject, error)
+	GetObject(userId uuid.UUID, href string) (entities.CalDAVObject, error)
+	PutObject(userId uuid.UUID, href string, data string, ifMatch string, ifNoneMatch string) (entities.CalDAVObject, bool, error)
+	DeleteObject(userId uuid.UUID, href string, ifMatch string) error
+}
+
+type calDAVService struct {
+	scheduleService ScheduleService
+	resourceRepo    repositories.CalDAVResourceRepository
+}
+
+func NewCalDAVService() CalDAVService {
+	return &calDAVService{
+		scheduleService: NewScheduleService(),
+		resourceRepo:    repositories.NewCalDAVResourceRepository(),
+	}
+}
+
+// NewCalDAVServiceWith builds the service on the given schedule service and
+// resource repository instead of the database-backed ones.
+func NewCalDAVServiceWith(scheduleService ScheduleService, resourceRepo repositories.CalDAVResourceRepository) CalDAVService {
+	return &calDAVService{
+		scheduleService: scheduleService,
+		resourceRepo:    resourceRepo,
+	}
+}
+
+func (s *calDAVService) GetCTag(userId uuid.UUID) (string, error) {
+	objects, err := s.GetObjects(userId, nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	tags := make([]string, 0, len(objects))
+	for _, object := range objects {
+		tags = append(tags, object.Href+":"+object.ETag)
+	}
+	sort.Strings(tags)
+
+	return calDAVHash(strings.Join(tags, "\n")), nil
+}
+
+func (s *calDAVService) GetObjects(userId uuid.UUID, start *time.Time, end *time.Time) ([]entities.CalDAVObject, error) {
+	schedules, err := s.scheduleService.GetAllSchedules(userId.String())
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := s.resourceRepo.GetCalDAVResourcesByUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	bySchedule := make(map[uuid.UUID]entities.CalDAVResource, len(resources))
+	for _, resource := range resources {
+		bySchedule[resource.ScheduleId] = resource
+	}
+
+	objects := make([]entities.CalDAVObject, 0, len(schedules))
+	for _, schedule := range schedules {
+		if start != nil && !schedule.EndTime.After(*start) {
+			continue
+		}
+		if end != nil && !schedule.StartTime.Before(*end) {
+			continue
+		}
+
+		var resource *entities.CalDAVResource
+		if r, ok := bySchedule[schedule.Id]; ok {
+			resource = &r
+		}
+		objects = append(objects, newCalDAVObject(schedule, resource))
+	}
+
+	return objects, nil
+}
+
+func (s *calDAVService) GetObject(userId uuid.UUID, href string) (entities.CalDAVObject, error) {
+	schedule, resource, err := s.resolve(userId, href)
+	if err != nil {
+		return entities.CalDAVObject{}, err
+	}
+
+	return newCalDAVObject(schedule, resource), nil
+}
+
+func (s *calDAVService) PutObject(userId uuid.UUID, href string, data string, ifMatch string, ifNoneMatch string) (entities.CalDAVObject, bool, error) {
+	calendar, err := utils.ParseICal(data)
+	if err != nil {
+		return entities.CalDAVObject{}, false, err
+	}
+
+	parsed, uid, err := scheduleFromVCalendar(calendar)
+	if err != nil {
+		return entities.CalDAVObject{}, false, err
+	}
+
+	existing, resource, err := s.resolve(userId, href)
+	if err != nil && !errors.Is(err, ErrCalDAVNotFound) {
+		return entities.CalDAVObject{}, false, err
+	}
+
+	if err == nil {
+		current := newCalDAVObject(existing, resource)
+		if ifNoneMatch == "*" || (ifMatch != "" && ifMatch != "*" && ifMatch != current.ETag) {
+			return entities.CalDAVObject{}, false, ErrCalDAVPreconditionFailed
+		}
+
+		parsed.Id = existing.Id
+		parsed.UserId = existing.UserId
+		parsed.Version = existing.Version
+		if parsed.Location == existing.Location {
+			parsed.RoomId = existing.RoomId
+		}
+		if err := s.scheduleService.UpdateSchedule(userId, parsed); err != nil {
+			return entities.CalDAVObject{}, false, err
+		}
+
+		return newCalDAVObject(parsed, resource), false, nil
+	}
+
+	if ifMatch != "" {
+		return entities.CalDAVObject{}, false, ErrCalDAVPreconditionFailed
+	}
+
+	parsed.Id = uuid.New()
+	parsed.UserId = userId
+	if uid == "" {
+		uid = parsed.Id.String()
+	}
+	if err := s.scheduleService.CreateNewSchedule(parsed); err != nil {
+		return entities.CalDAVObject{}, false, err
+	}
+
+	created := entities.CalDAVResource{
+		Id:         uuid.New(),
+		UserId:     userId,
+		ScheduleId: parsed.Id,
+		Href:       href,
+		Uid:        uid,
+	}
+	if err := s.resourceRepo.CreateNewCalDAVResource(created); err != nil {
+		return entities.CalDAVObject{}, false, err
+	}
+
+	return newCalDAVObject(parsed, &created), true, nil
+}
+
+func (s *calDAVService) DeleteObject(userId uuid.UUID, href string, ifMatch string) error {
+	schedule, resource, err := s.resolve(userId, href)
+	if err != nil {
+		return err
+	}
+
+	if ifMatch != "" && ifMatch != "*" && ifMatch != newCalDAVObject(schedule, resource).ETag {
+		return ErrCalDAVPreconditionFailed
+	}
+
+	if err := s.scheduleService.DeleteSchedule(schedule.Id, userId); err != nil {
+		return err
+	}
+
+	return s.resourceRepo.DeleteCalDAVResourceBySchedule(schedule.Id)
+}
+
+// resolve finds the schedule behind a resource name. Client-created objects are
+// looked up by their stored href; everything else is served as "<schedule id>.ics".
+func (s *calDAVService) resolve(userId uuid.UUID, href string) (entities.Schedule, *entities.CalDAVResource, error) {
+	resource, err := s.resourceRepo.FindCalDAVResourceByHref(userId, href)
+	if err == nil {
+		schedule, err := s.scheduleService.GetScheduleByID(resource.ScheduleId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Schedule{}, nil, ErrCalDAVNotFound
+		}
+		if err != nil {
+			return entities.Schedule{}, nil, err
+		}
+
+		return schedule, &resource, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.Schedule{}, nil, err
+	}
+
+	id, err := uuid.Parse(strings.TrimSuffix(href, ".ics"))
+	if err != nil {
+		return entities.Schedule{}, nil, ErrCalDAVNotFound
+	}
+
+	schedule, err := s.scheduleService.GetScheduleByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && schedule.UserId != userId) {
+		return entities.Schedule{}, nil, ErrCalDAVNotFound
+	}
+	if err != nil {
+		return entities.Schedule{}, nil, err
+	}
+
+	return schedule, nil, nil
+}
+
+func newCalDAVObject(schedule entities.Schedule, resource *entities.CalDAVResource) entities.CalDAVObject {
+	href := schedule.Id.String() + ".ics"
+	uid := schedule.Id.String()
+	if resource != nil {
+		href = resource.Href
+		uid = resource.Uid
+	}
+
+	data := scheduleToVCalendar(schedule, uid).Encode()
+
+	return entities.CalDAVObject{
+		Href:     href,
+		ETag:     `"` + calDAVHash(data) + `"`,
+		Data:     data,
+		Schedule: schedule,
+	}
+}
+
+func scheduleToVCalendar(schedule entities.Schedule, uid string) utils.ICalComponent {
+	return newVCalendar(scheduleToVEvent(schedule, uid))
+}
+
+func scheduleToVEvent(schedule entities.Schedule, uid string) utils.ICalComponent {
+	event := utils.ICalComponent{Name: "VEVENT"}
+	event.Add("UID", uid)
+	event.Add("DTSTAMP", utils.FormatICalDateTime(calDAVStamp))
+	if schedule.IsAllDay {
+		dateOnly := map[string]string{"VALUE": "DATE"}
+		event.AddWithParams("DTSTART", dateOnly, schedule.StartTime.UTC().Format(utils.ICalDateFormat))
+		event.AddWithParams("DTEND", dateOnly, schedule.EndTime.UTC().Format(utils.ICalDateFormat))
+	} else {
+		// Schedule times are wall-clock values, so they go out floating rather
+		// than as UTC instants.
+		event.Add("DTSTART", utils.FormatICalLocalDateTime(schedule.StartTime))
+		event.Add("DTEND", utils.FormatICalLocalDateTime(schedule.EndTime))
+	}
+	event.Add("SUMMARY", utils.EscapeICalText(schedule.Title))
+	if schedule.Description != "" {
+		event.Add("DESCRIPTION", utils.EscapeICalText(schedule.Description))
+	}
+	if schedule.Location != "" {
+		event.Add("LOCATION", utils.EscapeICalText(schedule.Location))
+	}
+	if schedule.Category != "" {
+		event.Add("CATEGORIES", utils.EscapeICalText(schedule.Category))
+	}
+
+	return event
+}
+
+func newVCalendar(components ...utils.ICalComponent) utils.ICalComponent {
+	calendar := utils.ICalComponent{Name: "VCALENDAR"}
+	calendar.Add("VERSION", "2.0")
+	calendar.Add("PRODID", calDAVProductID)
+	calendar.Components = append(calendar.Components, components...)
+
+	return calendar
+}
+
+func scheduleFromVCalendar(calendar utils.ICalComponent) (entities.Schedule, string, error) {
+	if calendar.Name != "VCALENDAR" {
+		return entities.Schedule{}, "", fmt.Errorf("%w: expected VCALENDAR", utils.ErrInvalidICal)
+	}
+
+	events := calendar.Find("VEVENT")
+	if len(events) == 0 {
+		return entities.Schedule{}, "", fmt.Errorf("%w: no VEVENT", utils.ErrInvalidICal)
+	}
+	event := events[0]
+
+	uidProp, _ := event.Get("UID")
+
+	startProp, ok := event.Get("DTSTART")
+	if !ok {
+		return entities.Schedule{}, "", fmt.Errorf("%w: missing DTSTART", utils.ErrInvalidICal)
+	}
+	startTime, isDate, err := utils.ParseICalDateTime(startProp, utils.ScheduleLocation())
+	if err != nil {
+		return entities.Schedule{}, "", fmt.Errorf("%w: %v", utils.ErrInvalidICal, err)
+	}
+
+	endTime := startTime
+	if isDate {
+		endTime = startTime.AddDate(0, 0, 1)
+	}
+	if endProp, ok := event.Get("DTEND"); ok {
+		endTime, _, err = utils.ParseICalDateTime(endProp, utils.ScheduleLocation())
+		if err != nil {
+			return entities.Schedule{}, "", fmt.Errorf("%w: %v", utils.ErrInvalidICal, err)
+		}
+	} else if durationProp, ok := event.Get("DURATION"); ok {
+		duration, err := utils.ParseICalDuration(durationProp.Value)
+		if err != nil {
+			return entities.Schedule{}, "", err
+		}
+		endTime = startTime.Add(duration)
+	}
+
+	if endTime.Before(startTime) {
+		return entities.Schedule{}, "", fmt.Errorf("%w: DTEND before DTSTART", utils.ErrInvalidICal)
+	}
+
+	text := func(name string) string {
+		prop, ok := event.Get(name)
+		if !ok {
+			return ""
+		}
+		return utils.UnescapeICalText(prop.Value)
+	}
+
+	category := ""
+	if prop, ok := event.Get("CATEGORIES"); ok {
+		first, _, _ := strings.Cut(prop.Value, ",")
+		category = utils.UnescapeICalText(first)
+	}
+
+	schedule := entities.Schedule{
+		StartTime:   startTime,
+		EndTime:     endTime,
+		IsAllDay:    isDate,
+		Title:       text("SUMMARY"),
+		Description: text("DESCRIPTION"),
+		Location:    text("LOCATION"),
+		Category:    category,
+	}
+
+	return schedule, uidProp.Value, nil
+}
+
+func calDAVHash(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
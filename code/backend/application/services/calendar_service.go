@@ -0,0 +1,114 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+)
+
+// CalendarService combines timed schedules and task deadlines into one calendar.
+type CalendarService interface {
+	GetCalendar(userId uuid.UUID, start time.Time, end time.Time) (entities.CalendarView, error)
+	ExportICal(userId uuid.UUID) (string, error)
+}
+
+type calendarService struct {
+	scheduleRepo repositories.ScheduleRepository
+	taskRepo     repositories.TaskRepository
+}
+
+func NewCalendarService() CalendarService {
+	return &calendarService{
+		scheduleRepo: repositories.NewScheduleRepository(),
+		taskRepo:     repositories.NewTaskRepository(),
+	}
+}
+
+// GetCalendar returns owned and accepted schedules overlapping [start, end)
+// together with the tasks due in that period.
+func (s *calendarService) GetCalendar(userId uuid.UUID, start time.Time, end time.Time) (entities.CalendarView, error) {
+	schedules, err := s.scheduleRepo.GetBusySchedulesByUser(userId, start, end)
+	if err != nil {
+		return entities.CalendarView{}, err
+	}
+
+	tasks, err := s.taskRepo.GetTasks(entities.TaskQuery{UserId: userId, DueFrom: &start, DueTo: &end})
+	if err != nil {
+		return entities.CalendarView{}, err
+	}
+
+	return entities.CalendarView{Schedules: schedules, Tasks: tasks}, nil
+}
+
+// ExportICal renders the user's own schedules as VEVENTs and their tasks as VTODOs.
+func (s *calendarService) ExportICal(userId uuid.UUID) (string, error) {
+	schedules, err := s.scheduleRepo.GetAllSchedules(userId.String())
+	if err != nil {
+		return "", err
+	}
+
+	tasks, err := s.taskRepo.GetTasks(entities.TaskQuery{UserId: userId})
+	if err != nil {
+		return "", err
+	}
+
+	components := make([]utils.ICalComponent, 0, len(schedules)+len(tasks))
+	for _, schedule := range schedules {
+		components = append(components, scheduleToVEvent(schedule, schedule.Id.String()))
+	}
+	for _, task := range tasks {
+		components = append(components, taskToVTodo(task))
+	}
+
+	return newVCalendar(components...).Encode(), nil
+}
+
+func taskToVTodo(task entities.Task) utils.ICalComponent {
+	todo := utils.ICalComponent{Name: "VTODO"}
+	todo.Add("UID", task.Id.String())
+	todo.Add("DTSTAMP", utils.FormatICalDateTime(calDAVStamp))
+	// Due dates are wall-clock values like schedule times, so they go out floating.
+	todo.Add("DUE", utils.FormatICalLocalDateTime(task.DueDate))
+	todo.Add("SUMMARY", utils.EscapeICalText(task.Title))
+	if task.Description != "" {
+		todo.Add("DESCRIPTION", utils.EscapeICalText(task.Description))
+	}
+	if task.Course != "" {
+		todo.Add("CATEGORIES", utils.EscapeICalText(task.Course))
+	}
+	todo.Add("PRIORITY", taskICalPriority(task.Priority))
+	if task.EstimatedMinutes > 0 {
+		// VTODO only allows DURATION together with DTSTART, so the estimate is an extension.
+		todo.Add("X-ESTIMATED-DURATION", fmt.Sprintf("PT%dM", task.EstimatedMinutes))
+	}
+	if task.ScheduleId != nil {
+		todo.Add("RELATED-TO", task.ScheduleId.String())
+	}
+
+	if task.IsCompleted {
+		todo.Add("STATUS", "COMPLETED")
+		if task.CompletedAt != nil {
+			todo.Add("COMPLETED", utils.FormatICalDateTime(*task.CompletedAt))
+		}
+	} else {
+		todo.Add("STATUS", "NEEDS-ACTION")
+	}
+
+	return todo
+}
+
+// taskICalPriority maps to RFC 5545 priorities, where 1 is highest and 9 lowest.
+func taskICalPriority(priority string) string {
+	switch priority {
+	case entities.TaskPriorityHigh:
+		return "1"
+	case entities.TaskPriorityLow:
+		return "9"
+	default:
+		return "5"
+	}
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidCategory   = errors.New("invalid category")
+	ErrCategoryNotFound  = errors.New("category not found")
+	ErrDuplicateCategory = errors.New("category already exists")
+)
+
+var categoryColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+const defaultCategoryColor = "#6b7280"
+
+type CategoryService interface {
+	CreateNewCategory(Category entities.Category) (entities.Category, error)
+	GetCategoryByID(id uuid.UUID) (entities.Category, error)
+	GetCategoriesByUser(userId uuid.UUID) ([]entities.Category, error)
+	UpdateCategory(Category entities.Category) error
+	DeleteCategory(id uuid.UUID, userId uuid.UUID) error
+	SeedDefaultCategories(userId uuid.UUID) error
+	ResolveScheduleCategory(Schedule *entities.Schedule) error
+}
+
+type categoryService struct {
+	repo repositories.CategoryRepository
+}
+
+func NewCategoryService() CategoryService {
+	return &categoryService{
+		repo: repositories.NewCategoryRepository(),
+	}
+}
+
+func (s *categoryService) CreateNewCategory(Category entities.Category) (entities.Category, error) {
+	Category.Id = uuid.New()
+	if err := s.validate(Category); err != nil {
+		return entities.Category{}, err
+	}
+
+	if err := s.repo.CreateNewCategory(Category); err != nil {
+		return entities.Category{}, err
+	}
+
+	return Category, nil
+}
+
+func (s *categoryService) GetCategoryByID(id uuid.UUID) (entities.Category, error) {
+	category, err := s.repo.FindCategory(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.Category{}, ErrCategoryNotFound
+	}
+	if err != nil {
+		return entities.Category{}, err
+	}
+
+	return category, nil
+}
+
+func (s *categoryService) GetCategoriesByUser(userId uuid.UUID) ([]entities.Category, error) {
+	categories, err := s.repo.GetCategoriesByUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (s *categoryService) UpdateCategory(Category entities.Category) error {
+	existing, err := s.GetCategoryByID(Category.Id)
+	if err != nil {
+		return err
+	}
+	if existing.UserId != Category.UserId {
+		return ErrCategoryNotFound
+	}
+
+	if err := s.validate(Category); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateCategory(Category)
+}
+
+func (s *categoryService) DeleteCategory(id uuid.UUID, userId uuid.UUID) error {
+	existing, err := s.GetCategoryByID(id)
+	if err != nil {
+		return err
+	}
+	if existing.UserId != userId {
+		return ErrCategoryNotFound
+	}
+
+	return s.repo.DeleteCategory(id)
+}
+
+func (s *categoryService) SeedDefaultCategories(userId uuid.UUID) error {
+	categories := make([]entities.Category, 0, len(entities.DefaultCategories))
+	for _, category := range entities.DefaultCategories {
+		category.Id = uuid.New()
+		category.UserId = userId
+		categories = append(categories, category)
+	}
+
+	return s.repo.BatchCreateNewCategory(categories)
+}
+
+// ResolveScheduleCategory keeps CategoryId and the legacy Category name in sync.
+// A known CategoryId wins; otherwise the name is looked up and created on first use.
+func (s *categoryService) ResolveScheduleCategory(Schedule *entities.Schedule) error {
+	if Schedule.CategoryId != nil {
+		category, err := s.GetCategoryByID(*Schedule.CategoryId)
+		if err != nil {
+			return err
+		}
+		if category.UserId != Schedule.UserId {
+			return ErrCategoryNotFound
+		}
+
+		Schedule.Category = category.Name
+		return nil
+	}
+
+	name := strings.TrimSpace(Schedule.Category)
+	if name == "" {
+		return nil
+	}
+
+	category, err := s.repo.FindCategoryByName(Schedule.UserId, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		category, err = s.CreateNewCategory(entities.Category{
+			UserId: Schedule.UserId,
+			Name:   name,
+			Color:  defaultCategoryColor,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	Schedule.CategoryId = &category.Id
+	Schedule.Category = category.Name
+	return nil
+}
+
+func (s *categoryService) validate(Category entities.Category) error {
+	if Category.UserId == uuid.Nil || strings.TrimSpace(Category.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCategory)
+	}
+	if !categoryColorPattern.MatchString(Category.Color) {
+		return fmt.Errorf("%w: color must look like #1a2b3c", ErrInvalidCategory)
+	}
+
+	existing, err := s.repo.FindCategoryByName(Category.UserId, strings.TrimSpace(Category.Name))
+	if err == nil && existing.Id != Category.Id {
+		return ErrDuplicateCategory
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return nil
+}
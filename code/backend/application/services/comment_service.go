@@ -0,0 +1,294 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidComment      = errors.New("invalid comment")
+	ErrCommentNotFound     = errors.New("comment not found")
+	ErrCommentThreadLocked = errors.New("comment thread is locked")
+)
+
+const (
+	maxCommentLength       = 4000
+	defaultCommentPageSize = 20
+	maxCommentPageSize     = 100
+)
+
+type CommentService interface {
+	CreateNewComment(Comment entities.Comment) (entities.Comment, error)
+	GetCommentsBySchedule(scheduleId uuid.UUID, userId uuid.UUID, page int, pageSize int) (entities.CommentPage, error)
+	UpdateComment(id uuid.UUID, userId uuid.UUID, body string, mentions []uuid.UUID) (entities.Comment, error)
+	DeleteComment(id uuid.UUID, userId uuid.UUID) error
+	SetThreadLocked(scheduleId uuid.UUID, userId uuid.UUID, locked bool) error
+}
+
+type commentService struct {
+	repo                repositories.CommentRepository
+	scheduleRepo        repositories.ScheduleRepository
+	notificationService NotificationService
+}
+
+func NewCommentService() CommentService {
+	return &commentService{
+		repo:                repositories.NewCommentRepository(),
+		scheduleRepo:        repositories.NewScheduleRepository(),
+		notificationService: NewNotificationService(),
+	}
+}
+
+func (s *commentService) CreateNewComment(Comment entities.Comment) (entities.Comment, error) {
+	schedule, members, err := s.authorize(Comment.ScheduleId, Comment.UserId)
+	if err != nil {
+		return entities.Comment{}, err
+	}
+	if err := s.checkUnlocked(schedule.Id); err != nil {
+		return entities.Comment{}, err
+	}
+
+	Comment.Body = strings.TrimSpace(Comment.Body)
+	if err := validateComment(Comment); err != nil {
+		return entities.Comment{}, err
+	}
+
+	Comment.Id = uuid.New()
+	Comment.CreatedAt = time.Now()
+	Comment.EditedAt = nil
+	Comment.Mentions = filterMentions(Comment.Mentions, members)
+
+	if err := s.repo.CreateNewComment(Comment); err != nil {
+		return entities.Comment{}, err
+	}
+
+	s.notifyMentions(schedule, Comment, Comment.Mentions)
+	return Comment, nil
+}
+
+// GetCommentsBySchedule pages through a thread oldest first. Page is 1-based.
+func (s *commentService) GetCommentsBySchedule(scheduleId uuid.UUID, userId uuid.UUID, page int, pageSize int) (entities.CommentPage, error) {
+	if _, _, err := s.authorize(scheduleId, userId); err != nil {
+		return entities.CommentPage{}, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultCommentPageSize
+	}
+	if pageSize > maxCommentPageSize {
+		pageSize = maxCommentPageSize
+	}
+
+	comments, total, err := s.repo.GetCommentsBySchedule(scheduleId, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return entities.CommentPage{}, err
+	}
+
+	locked, err := s.repo.IsThreadLocked(scheduleId)
+	if err != nil {
+		return entities.CommentPage{}, err
+	}
+
+	return entities.CommentPage{
+		Comments: comments,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+		Locked:   locked,
+	}, nil
+}
+
+// UpdateComment lets authors edit their own comments while the thread is open.
+// Only users newly mentioned by the edit are notified.
+func (s *commentService) UpdateComment(id uuid.UUID, userId uuid.UUID, body string, mentions []uuid.UUID) (entities.Comment, error) {
+	comment, err := s.find(id)
+	if err != nil {
+		return entities.Comment{}, err
+	}
+
+	schedule, members, err := s.authorize(comment.ScheduleId, userId)
+	if err != nil {
+		return entities.Comment{}, err
+	}
+	if comment.UserId != userId {
+		return entities.Comment{}, ErrScheduleForbidden
+	}
+	if err := s.checkUnlocked(schedule.Id); err != nil {
+		return entities.Comment{}, err
+	}
+
+	previous := comment.Mentions
+	now := time.Now()
+	comment.Body = strings.TrimSpace(body)
+	comment.Mentions = filterMentions(mentions, members)
+	comment.EditedAt = &now
+
+	if err := validateComment(comment); err != nil {
+		return entities.Comment{}, err
+	}
+
+	if err := s.repo.UpdateComment(comment); err != nil {
+		return entities.Comment{}, err
+	}
+
+	s.notifyMentions(schedule, comment, newMentions(previous, comment.Mentions))
+	return comment, nil
+}
+
+// DeleteComment is allowed for the author and for the organizer, even on a locked thread.
+func (s *commentService) DeleteComment(id uuid.UUID, userId uuid.UUID) error {
+	comment, err := s.find(id)
+	if err != nil {
+		return err
+	}
+
+	schedule, _, err := s.authorize(comment.ScheduleId, userId)
+	if err != nil {
+		return err
+	}
+	if comment.UserId != userId && schedule.UserId != userId {
+		return ErrScheduleForbidden
+	}
+
+	return s.repo.DeleteComment(id)
+}
+
+func (s *commentService) SetThreadLocked(scheduleId uuid.UUID, userId uuid.UUID, locked bool) error {
+	schedule, _, err := s.authorize(scheduleId, userId)
+	if err != nil {
+		return err
+	}
+	if schedule.UserId != userId {
+		return ErrScheduleForbidden
+	}
+
+	if !locked {
+		return s.repo.UnlockThread(scheduleId)
+	}
+
+	return s.repo.LockThread(entities.CommentThreadLock{
+		ScheduleId: scheduleId,
+		LockedBy:   userId,
+		LockedAt:   time.Now(),
+	})
+}
+
+func (s *commentService) find(id uuid.UUID) (entities.Comment, error) {
+	comment, err := s.repo.FindComment(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.Comment{}, ErrCommentNotFound
+	}
+
+	return comment, err
+}
+
+func (s *commentService) checkUnlocked(scheduleId uuid.UUID) error {
+	locked, err := s.repo.IsThreadLocked(scheduleId)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return ErrCommentThreadLocked
+	}
+
+	return nil
+}
+
+// authorize lets the owner and every invited participant into the thread and
+// returns that member set, which is also who may be mentioned.
+func (s *commentService) authorize(scheduleId uuid.UUID, userId uuid.UUID) (entities.Schedule, map[uuid.UUID]bool, error) {
+	schedule, err := s.scheduleRepo.FindSchedule(scheduleId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.Schedule{}, nil, ErrScheduleForbidden
+	}
+	if err != nil {
+		return entities.Schedule{}, nil, err
+	}
+
+	participants, err := s.scheduleRepo.GetAllScheduleRequestsBySchedule(scheduleId)
+	if err != nil {
+		return entities.Schedule{}, nil, err
+	}
+
+	members := map[uuid.UUID]bool{schedule.UserId: true}
+	for _, participant := range participants {
+		members[participant.UserId] = true
+	}
+
+	if !members[userId] {
+		return entities.Schedule{}, nil, ErrScheduleForbidden
+	}
+
+	return schedule, members, nil
+}
+
+func (s *commentService) notifyMentions(schedule entities.Schedule, Comment entities.Comment, mentions []uuid.UUID) {
+	scheduleId := schedule.Id
+
+	for _, userId := range mentions {
+		if userId == Comment.UserId {
+			continue
+		}
+
+		// A failed notification should not undo the comment itself.
+		s.notificationService.Notify(entities.Notification{
+			UserId:     userId,
+			ScheduleId: &scheduleId,
+			Type:       "Mention",
+			Title:      schedule.Title,
+			Message:    Comment.Body,
+		})
+	}
+}
+
+func validateComment(Comment entities.Comment) error {
+	if Comment.Body == "" {
+		return fmt.Errorf("%w: body is required", ErrInvalidComment)
+	}
+	if len(Comment.Body) > maxCommentLength {
+		return fmt.Errorf("%w: body is longer than %d characters", ErrInvalidComment, maxCommentLength)
+	}
+
+	return nil
+}
+
+// filterMentions drops duplicates and anyone who is not part of the schedule.
+func filterMentions(mentions []uuid.UUID, members map[uuid.UUID]bool) []uuid.UUID {
+	filtered := make([]uuid.UUID, 0, len(mentions))
+	seen := map[uuid.UUID]bool{}
+
+	for _, userId := range mentions {
+		if members[userId] && !seen[userId] {
+			seen[userId] = true
+			filtered = append(filtered, userId)
+		}
+	}
+
+	return filtered
+}
+
+func newMentions(previous []uuid.UUID, current []uuid.UUID) []uuid.UUID {
+	seen := map[uuid.UUID]bool{}
+	for _, userId := range previous {
+		seen[userId] = true
+	}
+
+	added := make([]uuid.UUID, 0)
+	for _, userId := range current {
+		if !seen[userId] {
+			added = append(added, userId)
+		}
+	}
+
+	return added
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidFreeTimeQuery = errors.New("invalid free time query")
+)
+
+const maxFreeTimeRangeDays = 62
+
+type FreeBusyService interface {
+	GetBusySlots(userIds []uuid.UUID, start time.Time, end time.Time) ([]entities.TimeSlot, error)
+	GetCommonFreeTime(query entities.FreeTimeQuery) ([]entities.TimeSlot, error)
+}
+
+type freeBusyService struct {
+	repo repositories.ScheduleRepository
+}
+
+func NewFreeBusyService() FreeBusyService {
+	return &freeBusyService{
+		repo: repositories.NewScheduleRepository(),
+	}
+}
+
+// GetBusySlots merges the owned and accepted schedules of every user into
+// non-overlapping busy slots. Only times are returned, never schedule details.
+// All-day schedules block their whole dates.
+func (s *freeBusyService) GetBusySlots(userIds []uuid.UUID, start time.Time, end time.Time) ([]entities.TimeSlot, error) {
+	busy := make([]entities.TimeSlot, 0)
+
+	for _, userId := range userIds {
+		schedules, err := s.repo.GetBusySchedulesByUser(userId, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, schedule := range schedules {
+			slot := scheduleTimeSlot(schedule)
+			if slot.StartTime.Before(end) && slot.EndTime.After(start) {
+				busy = append(busy, slot)
+			}
+		}
+	}
+
+	return mergeTimeSlots(busy), nil
+}
+
+func (s *freeBusyService) GetCommonFreeTime(query entities.FreeTimeQuery) ([]entities.TimeSlot, error) {
+	if len(query.UserIds) == 0 || query.EndDate.Before(query.StartDate) ||
+		query.DayStart < 0 || query.DayEnd > 24*time.Hour || query.DayStart >= query.DayEnd ||
+		query.MinDuration < 0 || query.EndDate.Sub(query.StartDate) > maxFreeTimeRangeDays*24*time.Hour {
+		return nil, ErrInvalidFreeTimeQuery
+	}
+
+	firstDay := utils.DateOf(query.StartDate)
+	lastDay := utils.DateOf(query.EndDate)
+
+	busy, err := s.GetBusySlots(query.UserIds, firstDay.Add(query.DayStart), lastDay.Add(query.DayEnd))
+	if err != nil {
+		return nil, err
+	}
+
+	free := make([]entities.TimeSlot, 0)
+	for day := firstDay; !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+		window := entities.TimeSlot{StartTime: day.Add(query.DayStart), EndTime: day.Add(query.DayEnd)}
+
+		for _, slot := range subtractTimeSlots(window, busy) {
+			if slot.EndTime.Sub(slot.StartTime) >= query.MinDuration && slot.EndTime.After(slot.StartTime) {
+				free = append(free, slot)
+			}
+		}
+	}
+
+	return free, nil
+}
+
+// scheduleTimeSlot is the time a schedule occupies. Schedule times are wall-clock
+// values held in UTC, so they compare directly with windows built the same way;
+// all-day schedules cover their whole dates.
+func scheduleTimeSlot(schedule entities.Schedule) entities.TimeSlot {
+	if !schedule.IsAllDay {
+		return entities.TimeSlot{StartTime: schedule.StartTime, EndTime: schedule.EndTime}
+	}
+
+	return entities.TimeSlot{
+		StartTime: utils.DateOf(schedule.StartTime.UTC()),
+		EndTime:   utils.DateOf(schedule.EndTime.UTC()),
+	}
+}
+
+// mergeTimeSlots sorts slots and joins the ones that overlap or touch.
+func mergeTimeSlots(slots []entities.TimeSlot) []entities.TimeSlot {
+	if len(slots) == 0 {
+		return slots
+	}
+
+	sorted := make([]entities.TimeSlot, len(slots))
+	copy(sorted, slots)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	merged := []entities.TimeSlot{sorted[0]}
+	for _, slot := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if !slot.StartTime.After(last.EndTime) {
+			if slot.EndTime.After(last.EndTime) {
+				last.EndTime = slot.EndTime
+			}
+			continue
+		}
+		merged = append(merged, slot)
+	}
+
+	return merged
+}
+
+// subtractTimeSlots removes merged, sorted busy slots from window.
+func subtractTimeSlots(window entities.TimeSlot, busy []entities.TimeSlot) []entities.TimeSlot {
+	free := make([]entities.TimeSlot, 0)
+	cursor := window.StartTime
+
+	for _, slot := range busy {
+		if !slot.EndTime.After(cursor) {
+			continue
+		}
+		if !slot.StartTime.Before(window.EndTime) {
+			break
+		}
+		if slot.StartTime.After(cursor) {
+			free = append(free, entities.TimeSlot{StartTime: cursor, EndTime: slot.StartTime})
+		}
+		cursor = slot.EndTime
+	}
+
+	if cursor.Before(window.EndTime) {
+		free = append(free, entities.TimeSlot{StartTime: cursor, EndTime: window.EndTime})
+	}
+
+	return free
+}
@@ -0,0 +1,364 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidGroupWorkQuery = errors.New("invalid group work query")
+	ErrInvalidSchedule       = errors.New("invalid schedule")
+)
+
+const (
+	groupWorkSlotStep          = 30 * time.Minute
+	groupWorkMaxHorizon        = 14 * 24 * time.Hour
+	groupWorkAdjacencyWindow   = 90 * time.Minute
+	groupWorkHistoryWindow     = 90 * 24 * time.Hour
+	groupWorkPreferenceFalloff = 3 * time.Hour
+	groupWorkDefaultLimit      = 10
+
+	availabilityWeight = 0.5
+	timeOfDayWeight    = 0.2
+	proximityWeight    = 0.15
+	fairnessWeight     = 0.15
+)
+
+type GroupWorkService interface {
+	RecommendSlots(query entities.GroupWorkQuery) ([]entities.SlotRecommendation, error)
+	BookSlot(schedule entities.Schedule, participantIds []uuid.UUID) error
+}
+
+type groupWorkService struct {
+	repo            repositories.ScheduleRepository
+	scheduleService ScheduleService
+}
+
+func NewGroupWorkService() GroupWorkService {
+	return &groupWorkService{
+		repo:            repositories.NewScheduleRepository(),
+		scheduleService: NewScheduleService(),
+	}
+}
+
+func (s *groupWorkService) RecommendSlots(query entities.GroupWorkQuery) ([]entities.SlotRecommendation, error) {
+	attendees := uniqueUserIds(append([]uuid.UUID{query.OrganizerId}, query.ParticipantIds...))
+
+	if query.OrganizerId == uuid.Nil || query.Duration <= 0 ||
+		query.EarliestStart.Add(query.Duration).After(query.Deadline) ||
+		query.DayStart >= query.DayEnd || query.PreferredStart > query.PreferredEnd {
+		return nil, ErrInvalidGroupWorkQuery
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = groupWorkDefaultLimit
+	}
+	deadline := query.Deadline
+	if deadline.Sub(query.EarliestStart) > groupWorkMaxHorizon {
+		deadline = query.EarliestStart.Add(groupWorkMaxHorizon)
+	}
+
+	schedules := make(map[uuid.UUID][]entities.Schedule, len(attendees))
+	busy := make(map[uuid.UUID][]entities.TimeSlot, len(attendees))
+	for _, attendee := range attendees {
+		owned, err := s.repo.GetBusySchedulesByUser(attendee, query.EarliestStart.Add(-groupWorkAdjacencyWindow), deadline.Add(groupWorkAdjacencyWindow))
+		if err != nil {
+			return nil, err
+		}
+
+		slots := make([]entities.TimeSlot, 0, len(owned))
+		for _, schedule := range owned {
+			slots = append(slots, scheduleTimeSlot(schedule))
+		}
+		schedules[attendee] = owned
+		busy[attendee] = mergeTimeSlots(slots)
+	}
+
+	missed, err := s.missedMeetings(query.OrganizerId, attendees, query.EarliestStart)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]entities.SlotRecommendation, 0)
+	first := query.EarliestStart.Truncate(groupWorkSlotStep)
+	if first.Before(query.EarliestStart) {
+		first = first.Add(groupWorkSlotStep)
+	}
+
+	for start := first; !start.Add(query.Duration).After(deadline); start = start.Add(groupWorkSlotStep) {
+		offset := start.Sub(utils.DateOf(start))
+		if offset < query.DayStart || offset+query.Duration > query.DayEnd {
+			continue
+		}
+
+		slot := entities.TimeSlot{StartTime: start, EndTime: start.Add(query.Duration)}
+
+		available := make([]uuid.UUID, 0, len(attendees))
+		unavailable := make([]uuid.UUID, 0)
+		for _, attendee := range attendees {
+			if overlapsAny(busy[attendee], slot) {
+				unavailable = append(unavailable, attendee)
+				continue
+			}
+			available = append(available, attendee)
+		}
+		if len(available) == 0 {
+			continue
+		}
+
+		availability := float64(len(available)) / float64(len(attendees))
+		timeOfDay := preferenceScore(offset, offset+query.Duration, query.PreferredStart, query.PreferredEnd)
+		proximity, suggested := proximityScore(slot, available, schedules, query.Location)
+		fairness := fairnessScore(available, attendees, missed)
+
+		candidates = append(candidates, entities.SlotRecommendation{
+			StartTime:          slot.StartTime,
+			EndTime:            slot.EndTime,
+			Score:              roundScore(availabilityWeight*availability + timeOfDayWeight*timeOfDay + proximityWeight*proximity + fairnessWeight*fairness),
+			AvailableCount:     len(available),
+			TotalCount:         len(attendees),
+			AvailableUserIds:   available,
+			UnavailableUserIds: unavailable,
+			AvailabilityScore:  roundScore(availability),
+			TimeOfDayScore:     roundScore(timeOfDay),
+			ProximityScore:     roundScore(proximity),
+			FairnessScore:      roundScore(fairness),
+			SuggestedLocation:  suggested,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	// Keep the ranking useful by not returning several shifted copies of the same slot.
+	recommendations := make([]entities.SlotRecommendation, 0, limit)
+	for _, candidate := range candidates {
+		if len(recommendations) == limit {
+			break
+		}
+
+		clashes := false
+		for _, picked := range recommendations {
+			if candidate.StartTime.Before(picked.EndTime) && candidate.EndTime.After(picked.StartTime) {
+				clashes = true
+				break
+			}
+		}
+		if !clashes {
+			recommendations = append(recommendations, candidate)
+		}
+	}
+
+	return recommendations, nil
+}
+
+func (s *groupWorkService) BookSlot(schedule entities.Schedule, participantIds []uuid.UUID) error {
+	if schedule.UserId == uuid.Nil || !schedule.EndTime.After(schedule.StartTime) || strings.TrimSpace(schedule.Title) == "" {
+		return ErrInvalidSchedule
+	}
+
+	return s.scheduleService.CreateScheduleWithParticipants(schedule, uniqueUserIds(participantIds))
+}
+
+// missedMeetings counts, per attendee, the organizer's recent meetings with this
+// group that the attendee was left out of or did not accept.
+func (s *groupWorkService) missedMeetings(organizerId uuid.UUID, attendees []uuid.UUID, now time.Time) (map[uuid.UUID]int, error) {
+	participants, err := s.repo.GetParticipantsByScheduleOwner(organizerId, now.Add(-groupWorkHistoryWindow), now)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[uuid.UUID]bool, len(attendees))
+	for _, attendee := range attendees {
+		if attendee != organizerId {
+			members[attendee] = true
+		}
+	}
+
+	bySchedule := make(map[uuid.UUID]map[uuid.UUID]string)
+	for _, participant := range participants {
+		if !members[participant.UserId] {
+			continue
+		}
+		if bySchedule[participant.ScheduleId] == nil {
+			bySchedule[participant.ScheduleId] = make(map[uuid.UUID]string)
+		}
+		bySchedule[participant.ScheduleId][participant.UserId] = participant.Status
+	}
+
+	// A past meeting counts as a meeting of this group when at least half of the members were invited.
+	quorum := (len(members) + 1) / 2
+	if quorum == 0 {
+		quorum = 1
+	}
+
+	missed := make(map[uuid.UUID]int, len(members))
+	for _, statuses := range bySchedule {
+		if len(statuses) < quorum {
+			continue
+		}
+		for member := range members {
+			if statuses[member] != "Accepted" {
+				missed[member]++
+			}
+		}
+	}
+
+	return missed, nil
+}
+
+func overlapsAny(busy []entities.TimeSlot, slot entities.TimeSlot) bool {
+	for _, b := range busy {
+		if b.StartTime.Before(slot.EndTime) && b.EndTime.After(slot.StartTime) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// preferenceScore is 1 inside the preferred window and falls off linearly with
+// the time spent outside it.
+func preferenceScore(start time.Duration, end time.Duration, preferredStart time.Duration, preferredEnd time.Duration) float64 {
+	outside := time.Duration(0)
+	if start < preferredStart {
+		outside += preferredStart - start
+	}
+	if end > preferredEnd {
+		outside += end - preferredEnd
+	}
+
+	return math.Max(0, 1-float64(outside)/float64(groupWorkPreferenceFalloff))
+}
+
+// proximityScore rewards slots where available attendees have a class right before
+// or after in the meeting's building. Without a location the most common nearby
+// building is used and suggested back to the caller.
+func proximityScore(slot entities.TimeSlot, available []uuid.UUID, schedules map[uuid.UUID][]entities.Schedule, location string) (float64, string) {
+	adjacent := make(map[uuid.UUID][]entities.Schedule, len(available))
+	buildingCount := map[string]int{}
+	locationCount := map[string]int{}
+
+	for _, attendee := range available {
+		for _, schedule := range schedules[attendee] {
+			before := !schedule.EndTime.After(slot.StartTime) && slot.StartTime.Sub(schedule.EndTime) <= groupWorkAdjacencyWindow
+			after := !schedule.StartTime.Before(slot.EndTime) && schedule.StartTime.Sub(slot.EndTime) <= groupWorkAdjacencyWindow
+			if !before && !after {
+				continue
+			}
+
+			adjacent[attendee] = append(adjacent[attendee], schedule)
+			if building := buildingOf(schedule.Location); building != "" {
+				buildingCount[building]++
+				locationCount[schedule.Location]++
+			}
+		}
+	}
+
+	suggested := location
+	target := buildingOf(location)
+	if location == "" {
+		best := 0
+		for building, count := range buildingCount {
+			if count > best || (count == best && building < target) {
+				target, best = building, count
+			}
+		}
+
+		best = 0
+		for candidate, count := range locationCount {
+			if buildingOf(candidate) == target && (count > best || (count == best && candidate < suggested)) {
+				suggested, best = candidate, count
+			}
+		}
+	}
+
+	if target == "" {
+		return 0.5, suggested
+	}
+
+	total := 0.0
+	for _, attendee := range available {
+		nearby := adjacent[attendee]
+		if len(nearby) == 0 {
+			total += 0.5
+			continue
+		}
+
+		for _, schedule := range nearby {
+			if buildingOf(schedule.Location) == target {
+				total += 1
+				break
+			}
+		}
+	}
+
+	return total / float64(len(available)), suggested
+}
+
+// buildingOf maps a free-form location to a building. "Tower A - Room 5" yields
+// "Tower A"; numbered rooms are grouped by their leading digit, so "Room 203" and
+// "Room 205" share a building; named places such as "AI Lab" stand on their own.
+func buildingOf(location string) string {
+	location = strings.TrimSpace(location)
+	if location == "" || strings.EqualFold(location, "Online") {
+		return ""
+	}
+
+	if building, _, found := strings.Cut(location, " - "); found {
+		return strings.TrimSpace(building)
+	}
+
+	fields := strings.Fields(location)
+	last := fields[len(fields)-1]
+	if len(fields) > 1 && unicode.IsDigit(rune(last[0])) {
+		return strings.Join(fields[:len(fields)-1], " ") + " " + last[:1]
+	}
+
+	return location
+}
+
+// fairnessScore weights each attendee by how many recent group meetings they missed,
+// so slots that include previously excluded members rank higher.
+func fairnessScore(available []uuid.UUID, attendees []uuid.UUID, missed map[uuid.UUID]int) float64 {
+	total := 0.0
+	for _, attendee := range attendees {
+		total += float64(1 + missed[attendee])
+	}
+
+	covered := 0.0
+	for _, attendee := range available {
+		covered += float64(1 + missed[attendee])
+	}
+
+	return covered / total
+}
+
+func uniqueUserIds(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if id == uuid.Nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+
+	return unique
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
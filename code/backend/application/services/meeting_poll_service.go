@@ -0,0 +1,381 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const maxMeetingPollOptions = 20
+
+var (
+	ErrInvalidMeetingPoll   = errors.New("invalid meeting poll")
+	ErrMeetingPollNotFound  = errors.New("meeting poll not found")
+	ErrMeetingPollForbidden = errors.New("only the organizer can do this")
+	ErrMeetingPollClosed    = repositories.ErrPollNotOpen
+)
+
+type MeetingPollService interface {
+	CreateNewMeetingPoll(Poll entities.MeetingPoll, Options []entities.MeetingPollOption) (entities.MeetingPollResponse, error)
+	GetMeetingPoll(id uuid.UUID, userId uuid.UUID) (entities.MeetingPollResponse, error)
+	GetMeetingPollsByUser(userId uuid.UUID) ([]entities.MeetingPoll, error)
+	Vote(id uuid.UUID, userId uuid.UUID, Votes []entities.MeetingPollVote) (entities.MeetingPollResponse, error)
+	FinalizeMeetingPoll(id uuid.UUID, userId uuid.UUID, optionId uuid.UUID) (entities.Schedule, error)
+	CancelMeetingPoll(id uuid.UUID, userId uuid.UUID) error
+}
+
+type meetingPollService struct {
+	repo        repositories.MeetingPollRepository
+	userRepo    repositories.UserRepository
+	roomService RoomService
+	schedules   *scheduleService
+}
+
+func NewMeetingPollService() MeetingPollService {
+	schedules := newScheduleService()
+	return &meetingPollService{
+		repo:        repositories.NewMeetingPollRepository(),
+		userRepo:    schedules.userRepo,
+		roomService: schedules.roomService,
+		schedules:   schedules,
+	}
+}
+
+// CreateNewMeetingPoll opens a poll over the given options and notifies the invitees.
+func (s *meetingPollService) CreateNewMeetingPoll(Poll entities.MeetingPoll, Options []entities.MeetingPollOption) (entities.MeetingPollResponse, error) {
+	Poll.Id = uuid.New()
+	Poll.Status = entities.MeetingPollOpen
+	Poll.FinalOptionId = nil
+	Poll.ScheduleId = nil
+
+	if err := s.validate(&Poll, Options); err != nil {
+		return entities.MeetingPollResponse{}, err
+	}
+
+	options := make([]entities.MeetingPollOption, 0, len(Options))
+	for _, option := range Options {
+		options = append(options, entities.MeetingPollOption{
+			Id:        uuid.New(),
+			PollId:    Poll.Id,
+			StartTime: option.StartTime,
+			EndTime:   option.EndTime,
+		})
+	}
+
+	if err := s.repo.CreateNewMeetingPoll(Poll, options); err != nil {
+		return entities.MeetingPollResponse{}, err
+	}
+
+	s.notify(Poll, Poll.InviteeIds, "MeetingPollCreated", fmt.Sprintf("Vote on a time for %s", Poll.Title))
+
+	return s.GetMeetingPoll(Poll.Id, Poll.UserId)
+}
+
+// GetMeetingPoll returns the poll with its vote tally to its organizer and invitees.
+func (s *meetingPollService) GetMeetingPoll(id uuid.UUID, userId uuid.UUID) (entities.MeetingPollResponse, error) {
+	poll, err := s.findVisible(id, userId)
+	if err != nil {
+		return entities.MeetingPollResponse{}, err
+	}
+
+	options, err := s.repo.GetMeetingPollOptions(id)
+	if err != nil {
+		return entities.MeetingPollResponse{}, err
+	}
+
+	votes, err := s.repo.GetMeetingPollVotes(id)
+	if err != nil {
+		return entities.MeetingPollResponse{}, err
+	}
+
+	answers := make(map[uuid.UUID]map[uuid.UUID]string, len(options))
+	for _, vote := range votes {
+		if answers[vote.OptionId] == nil {
+			answers[vote.OptionId] = make(map[uuid.UUID]string)
+		}
+		answers[vote.OptionId][vote.UserId] = vote.Answer
+	}
+
+	summaries := make([]entities.MeetingPollOptionSummary, 0, len(options))
+	for _, option := range options {
+		summary := entities.MeetingPollOptionSummary{
+			Option:  option,
+			Yes:     make([]uuid.UUID, 0),
+			Maybe:   make([]uuid.UUID, 0),
+			No:      make([]uuid.UUID, 0),
+			Pending: make([]uuid.UUID, 0),
+		}
+		// Only current invitees count, in invitation order.
+		for _, inviteeId := range poll.InviteeIds {
+			switch answers[option.Id][inviteeId] {
+			case entities.VoteYes:
+				summary.Yes = append(summary.Yes, inviteeId)
+			case entities.VoteMaybe:
+				summary.Maybe = append(summary.Maybe, inviteeId)
+			case entities.VoteNo:
+				summary.No = append(summary.No, inviteeId)
+			default:
+				summary.Pending = append(summary.Pending, inviteeId)
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return entities.MeetingPollResponse{Poll: poll, Options: summaries}, nil
+}
+
+func (s *meetingPollService) GetMeetingPollsByUser(userId uuid.UUID) ([]entities.MeetingPoll, error) {
+	return s.repo.GetMeetingPollsByUser(userId)
+}
+
+// Vote records an invitee's answers. Options left out keep their earlier answer.
+func (s *meetingPollService) Vote(id uuid.UUID, userId uuid.UUID, Votes []entities.MeetingPollVote) (entities.MeetingPollResponse, error) {
+	poll, err := s.findVisible(id, userId)
+	if err != nil {
+		return entities.MeetingPollResponse{}, err
+	}
+	if !isInvitee(poll, userId) {
+		return entities.MeetingPollResponse{}, fmt.Errorf("%w: only invitees vote", ErrInvalidMeetingPoll)
+	}
+	if poll.Status != entities.MeetingPollOpen {
+		return entities.MeetingPollResponse{}, ErrMeetingPollClosed
+	}
+	if len(Votes) == 0 {
+		return entities.MeetingPollResponse{}, fmt.Errorf("%w: no votes given", ErrInvalidMeetingPoll)
+	}
+
+	options, err := s.repo.GetMeetingPollOptions(id)
+	if err != nil {
+		return entities.MeetingPollResponse{}, err
+	}
+	known := make(map[uuid.UUID]bool, len(options))
+	for _, option := range options {
+		known[option.Id] = true
+	}
+
+	seen := make(map[uuid.UUID]bool, len(Votes))
+	votes := make([]entities.MeetingPollVote, 0, len(Votes))
+	for _, vote := range Votes {
+		if !known[vote.OptionId] || seen[vote.OptionId] {
+			return entities.MeetingPollResponse{}, fmt.Errorf("%w: unknown or repeated option %s", ErrInvalidMeetingPoll, vote.OptionId)
+		}
+		seen[vote.OptionId] = true
+
+		answer := strings.ToLower(strings.TrimSpace(vote.Answer))
+		if answer != entities.VoteYes && answer != entities.VoteMaybe && answer != entities.VoteNo {
+			return entities.MeetingPollResponse{}, fmt.Errorf("%w: answer must be yes, maybe or no", ErrInvalidMeetingPoll)
+		}
+
+		votes = append(votes, entities.MeetingPollVote{
+			Id:       uuid.New(),
+			PollId:   id,
+			OptionId: vote.OptionId,
+			UserId:   userId,
+			Answer:   answer,
+		})
+	}
+
+	if err := s.repo.ReplaceVotes(id, userId, votes); err != nil {
+		return entities.MeetingPollResponse{}, err
+	}
+
+	return s.GetMeetingPoll(id, userId)
+}
+
+// FinalizeMeetingPoll turns the chosen option into a schedule owned by the
+// organizer. Invitees who voted yes on it are added as Accepted, everyone else
+// is invited as Pending.
+func (s *meetingPollService) FinalizeMeetingPoll(id uuid.UUID, userId uuid.UUID, optionId uuid.UUID) (entities.Schedule, error) {
+	response, err := s.GetMeetingPoll(id, userId)
+	if err != nil {
+		return entities.Schedule{}, err
+	}
+	poll := response.Poll
+	if poll.UserId != userId {
+		return entities.Schedule{}, ErrMeetingPollForbidden
+	}
+	if poll.Status != entities.MeetingPollOpen {
+		return entities.Schedule{}, ErrMeetingPollClosed
+	}
+
+	var chosen *entities.MeetingPollOptionSummary
+	for i := range response.Options {
+		if response.Options[i].Option.Id == optionId {
+			chosen = &response.Options[i]
+		}
+	}
+	if chosen == nil {
+		return entities.Schedule{}, fmt.Errorf("%w: option %s is not part of this poll", ErrInvalidMeetingPoll, optionId)
+	}
+
+	schedule := entities.Schedule{
+		Id:          uuid.New(),
+		UserId:      poll.UserId,
+		StartTime:   chosen.Option.StartTime,
+		EndTime:     chosen.Option.EndTime,
+		Title:       poll.Title,
+		Description: poll.Description,
+		Location:    poll.Location,
+		RoomId:      poll.RoomId,
+		Category:    poll.Category,
+		CategoryId:  poll.CategoryId,
+		Visibility:  poll.Visibility,
+	}
+	if err := s.schedules.prepare(&schedule); err != nil {
+		return entities.Schedule{}, err
+	}
+
+	accepted := make(map[uuid.UUID]bool, len(chosen.Yes))
+	for _, voterId := range chosen.Yes {
+		accepted[voterId] = true
+	}
+
+	participants := make([]entities.ScheduleParticipant, 0, len(poll.InviteeIds))
+	for _, inviteeId := range poll.InviteeIds {
+		status := "Pending"
+		if accepted[inviteeId] {
+			status = "Accepted"
+		}
+		participants = append(participants, entities.ScheduleParticipant{
+			Id:         uuid.New(),
+			ScheduleId: schedule.Id,
+			UserId:     inviteeId,
+			Status:     status,
+		})
+	}
+
+	poll.FinalOptionId = &optionId
+	if err := s.repo.FinalizeMeetingPoll(poll, schedule, participants); err != nil {
+		return entities.Schedule{}, err
+	}
+
+	poll.ScheduleId = &schedule.Id
+	s.schedules.revisionService.Record(entities.RevisionCreated, userId, nil, &schedule)
+	s.notify(poll, poll.InviteeIds, "MeetingPollFinalized",
+		fmt.Sprintf("%s is set for %s", poll.Title, utils.FormatScheduleTime(schedule.StartTime)))
+
+	return s.schedules.GetScheduleByID(schedule.Id)
+}
+
+func (s *meetingPollService) CancelMeetingPoll(id uuid.UUID, userId uuid.UUID) error {
+	poll, err := s.findVisible(id, userId)
+	if err != nil {
+		return err
+	}
+	if poll.UserId != userId {
+		return ErrMeetingPollForbidden
+	}
+
+	if err := s.repo.CancelMeetingPoll(id); err != nil {
+		return err
+	}
+
+	s.notify(poll, poll.InviteeIds, "MeetingPollCancelled", fmt.Sprintf("The poll for %s was cancelled", poll.Title))
+	return nil
+}
+
+// findVisible hides polls from users who are neither organizer nor invitee.
+func (s *meetingPollService) findVisible(id uuid.UUID, userId uuid.UUID) (entities.MeetingPoll, error) {
+	poll, err := s.repo.FindMeetingPoll(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.MeetingPoll{}, ErrMeetingPollNotFound
+	}
+	if err != nil {
+		return entities.MeetingPoll{}, err
+	}
+	if poll.UserId != userId && !isInvitee(poll, userId) {
+		return entities.MeetingPoll{}, ErrMeetingPollNotFound
+	}
+
+	return poll, nil
+}
+
+func isInvitee(poll entities.MeetingPoll, userId uuid.UUID) bool {
+	for _, inviteeId := range poll.InviteeIds {
+		if inviteeId == userId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *meetingPollService) validate(Poll *entities.MeetingPoll, Options []entities.MeetingPollOption) error {
+	Poll.Title = strings.TrimSpace(Poll.Title)
+
+	if Poll.UserId == uuid.Nil || Poll.Title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidMeetingPoll)
+	}
+	if len(Options) == 0 || len(Options) > maxMeetingPollOptions {
+		return fmt.Errorf("%w: give between 1 and %d options", ErrInvalidMeetingPoll, maxMeetingPollOptions)
+	}
+
+	seen := make(map[string]bool, len(Options))
+	for _, option := range Options {
+		if !option.EndTime.After(option.StartTime) {
+			return fmt.Errorf("%w: every option must end after it starts", ErrInvalidMeetingPoll)
+		}
+		key := option.StartTime.String() + option.EndTime.String()
+		if seen[key] {
+			return fmt.Errorf("%w: options must not repeat", ErrInvalidMeetingPoll)
+		}
+		seen[key] = true
+	}
+
+	probe := entities.Schedule{Visibility: Poll.Visibility}
+	if err := normalizeVisibility(&probe); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMeetingPoll, err)
+	}
+	Poll.Visibility = probe.Visibility
+
+	if Poll.RoomId != nil {
+		room, err := s.roomService.GetRoomByID(*Poll.RoomId)
+		if errors.Is(err, ErrRoomNotFound) {
+			return fmt.Errorf("%w: room does not exist", ErrInvalidMeetingPoll)
+		}
+		if err != nil {
+			return err
+		}
+		Poll.Location = room.Name
+	}
+
+	inviteeIds := make([]uuid.UUID, 0, len(Poll.InviteeIds))
+	for _, inviteeId := range uniqueUserIds(Poll.InviteeIds) {
+		if inviteeId == Poll.UserId {
+			continue
+		}
+		if _, err := s.userRepo.FindUser(inviteeId); errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: invitee %s does not exist", ErrInvalidMeetingPoll, inviteeId)
+		} else if err != nil {
+			return err
+		}
+		inviteeIds = append(inviteeIds, inviteeId)
+	}
+	if len(inviteeIds) == 0 {
+		return fmt.Errorf("%w: invite at least one other user", ErrInvalidMeetingPoll)
+	}
+	Poll.InviteeIds = inviteeIds
+
+	return nil
+}
+
+func (s *meetingPollService) notify(poll entities.MeetingPoll, userIds []uuid.UUID, kind string, message string) {
+	for _, userId := range userIds {
+		if err := s.schedules.notificationService.Notify(entities.Notification{
+			UserId:     userId,
+			ScheduleId: poll.ScheduleId,
+			Type:       kind,
+			Title:      poll.Title,
+			Message:    message,
+		}); err != nil {
+			log.Println(err)
+		}
+	}
+}
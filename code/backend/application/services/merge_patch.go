@@ -0,0 +1,116 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MergePatch is a JSON Merge Patch (RFC 7396) document. Each member replaces
+// the field of the same name and null clears it; the records patched here are
+// flat, so there is no nested merging.
+type MergePatch map[string]json.RawMessage
+
+// FieldErrors maps each rejected patch member to the reason it was rejected.
+type FieldErrors map[string]string
+
+func (e FieldErrors) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	return "invalid fields: " + strings.Join(fields, ", ")
+}
+
+var (
+	errUnknownField  = errors.New("unknown field")
+	errNullField     = errors.New("cannot be null")
+	errEmptyField    = errors.New("cannot be empty")
+	errStringField   = errors.New("must be a string")
+	errBoolField     = errors.New("must be a boolean")
+	errVersionField  = errors.New("must be a positive integer")
+	errUUIDField     = errors.New("must be a UUID or null")
+	errDateTimeField = errors.New("must be a date or date-time")
+)
+
+func isNull(raw json.RawMessage) bool {
+	return strings.TrimSpace(string(raw)) == "null"
+}
+
+// patchString reads a string member; null clears it unless it is required.
+func patchString(raw json.RawMessage, required bool) (string, error) {
+	if isNull(raw) {
+		if required {
+			return "", errNullField
+		}
+		return "", nil
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", errStringField
+	}
+	if required && strings.TrimSpace(value) == "" {
+		return "", errEmptyField
+	}
+
+	return value, nil
+}
+
+func patchBool(raw json.RawMessage) (bool, error) {
+	if isNull(raw) {
+		return false, errNullField
+	}
+
+	var value bool
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return false, errBoolField
+	}
+
+	return value, nil
+}
+
+func patchVersion(raw json.RawMessage) (int, error) {
+	var value int
+	if err := json.Unmarshal(raw, &value); err != nil || value <= 0 {
+		return 0, errVersionField
+	}
+
+	return value, nil
+}
+
+func patchUUID(raw json.RawMessage) (*uuid.UUID, error) {
+	if isNull(raw) {
+		return nil, nil
+	}
+
+	var value uuid.UUID
+	if err := json.Unmarshal(raw, &value); err != nil || value == uuid.Nil {
+		return nil, errUUIDField
+	}
+
+	return &value, nil
+}
+
+// patchTime accepts RFC 3339, the "2006-01-02T15:04:05" form the create
+// endpoints use, or a plain date.
+func patchTime(raw json.RawMessage) (time.Time, error) {
+	value, err := patchString(raw, true)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errDateTimeField
+}
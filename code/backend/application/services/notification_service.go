@@ -0,0 +1,49 @@
+package services
+
+import (
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+)
+
+type NotificationService interface {
+	Notify(notification entities.Notification) error
+	GetNotificationsByUser(userId uuid.UUID) ([]entities.Notification, error)
+	MarkAsRead(id uuid.UUID, userId uuid.UUID) error
+}
+
+type notificationService struct {
+	repo repositories.NotificationRepository
+}
+
+func NewNotificationService() NotificationService {
+	return &notificationService{
+		repo: repositories.NewNotificationRepository(),
+	}
+}
+
+func (s *notificationService) Notify(notification entities.Notification) error {
+	if notification.Id == uuid.Nil {
+		notification.Id = uuid.New()
+	}
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	return s.repo.CreateNewNotification(notification)
+}
+
+func (s *notificationService) GetNotificationsByUser(userId uuid.UUID) ([]entities.Notification, error) {
+	notifications, err := s.repo.GetNotificationsByUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (s *notificationService) MarkAsRead(id uuid.UUID, userId uuid.UUID) error {
+	return s.repo.MarkNotificationRead(id, userId, time.Now())
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid personal token")
+)
+
+// Personal tokens are shown to the user once; only their SHA-256 hash is stored.
+const personalTokenPrefix = "rus_"
+
+type PersonalTokenService interface {
+	CreatePersonalToken(userId uuid.UUID, name string) (entities.PersonalTokenResponse, error)
+	GetPersonalTokensByUser(userId uuid.UUID) ([]entities.PersonalToken, error)
+	RevokePersonalToken(id uuid.UUID, userId uuid.UUID) error
+	Authenticate(token string) (entities.User, error)
+}
+
+type personalTokenService struct {
+	repo     repositories.PersonalTokenRepository
+	userRepo repositories.UserRepository
+}
+
+func NewPersonalTokenService() PersonalTokenService {
+	return &personalTokenService{
+		repo:     repositories.NewPersonalTokenRepository(),
+		userRepo: repositories.NewUserRepository(),
+	}
+}
+
+func (s *personalTokenService) CreatePersonalToken(userId uuid.UUID, name string) (entities.PersonalTokenResponse, error) {
+	if _, err := s.userRepo.FindUser(userId); err != nil {
+		return entities.PersonalTokenResponse{}, ErrUserNotFound
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return entities.PersonalTokenResponse{}, err
+	}
+	token := personalTokenPrefix + hex.EncodeToString(secret)
+
+	if strings.TrimSpace(name) == "" {
+		name = "Personal token"
+	}
+
+	personalToken := entities.PersonalToken{
+		Id:        uuid.New(),
+		UserId:    userId,
+		Name:      name,
+		TokenHash: hashPersonalToken(token),
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.repo.CreateNewPersonalToken(personalToken); err != nil {
+		return entities.PersonalTokenResponse{}, err
+	}
+
+	return entities.PersonalTokenResponse{
+		PersonalToken: personalToken,
+		Token:         token,
+	}, nil
+}
+
+func (s *personalTokenService) GetPersonalTokensByUser(userId uuid.UUID) ([]entities.PersonalToken, error) {
+	tokens, err := s.repo.GetPersonalTokensByUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (s *personalTokenService) RevokePersonalToken(id uuid.UUID, userId uuid.UUID) error {
+	return s.repo.DeletePersonalToken(id, userId)
+}
+
+func (s *personalTokenService) Authenticate(token string) (entities.User, error) {
+	if !strings.HasPrefix(token, personalTokenPrefix) {
+		return entities.User{}, ErrInvalidToken
+	}
+
+	personalToken, err := s.repo.FindPersonalTokenByHash(hashPersonalToken(token))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.User{}, ErrInvalidToken
+	}
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	user, err := s.userRepo.FindUser(personalToken.UserId)
+	if err != nil || !user.IsActive {
+		return entities.User{}, ErrInvalidToken
+	}
+
+	// Best effort: a failed timestamp update should not block the request.
+	_ = s.repo.TouchPersonalToken(personalToken.Id, time.Now())
+
+	return user, nil
+}
+
+func hashPersonalToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidQuickAdd = errors.New("invalid quick add")
+)
+
+const maxQuickAddLength = 500
+
+type QuickAddService interface {
+	ParseQuickAdd(userId uuid.UUID, text string, now time.Time) (entities.QuickAddDraft, error)
+}
+
+type quickAddService struct {
+	userService     UserService
+	categoryService CategoryService
+	roomRepo        repositories.RoomRepository
+}
+
+func NewQuickAddService() QuickAddService {
+	return &quickAddService{
+		userService:     NewUserService(),
+		categoryService: NewCategoryService(),
+		roomRepo:        repositories.NewRoomRepository(),
+	}
+}
+
+// ParseQuickAdd turns text like "Study with Alice tomorrow 3-5pm at Room 101
+// #Study" into a draft. Relative words are read against now, in now's location.
+// The draft's times are wall-clock times in UTC, the same way the create
+// endpoints store them. Names are matched against the users the user follows,
+// the #tag against their categories and the location against room names.
+func (s *quickAddService) ParseQuickAdd(userId uuid.UUID, text string, now time.Time) (entities.QuickAddDraft, error) {
+	text = strings.TrimSpace(text)
+	if userId == uuid.Nil || text == "" || len(text) > maxQuickAddLength {
+		return entities.QuickAddDraft{}, ErrInvalidQuickAdd
+	}
+
+	parsed := utils.ParseQuickAdd(text, now)
+
+	draft := entities.QuickAddDraft{
+		Schedule: entities.Schedule{
+			UserId:     userId,
+			StartTime:  utils.WallClock(parsed.Start),
+			EndTime:    utils.WallClock(parsed.End),
+			IsAllDay:   parsed.IsAllDay,
+			Title:      parsed.Title,
+			Location:   parsed.Location,
+			Category:   parsed.Category,
+			Visibility: entities.VisibilityBusy,
+		},
+		Participants:    make([]entities.QuickAddParticipant, 0, len(parsed.Names)),
+		UnresolvedNames: make([]string, 0),
+	}
+	if draft.Schedule.Title == "" {
+		draft.Schedule.Title = parsed.Category
+	}
+
+	if err := s.resolveCategory(&draft); err != nil {
+		return entities.QuickAddDraft{}, err
+	}
+
+	if parsed.Location != "" {
+		if room, err := s.roomRepo.FindRoomByName(parsed.Location); err == nil {
+			draft.Schedule.RoomId = &room.Id
+			draft.Schedule.Location = room.Name
+		}
+	}
+
+	if len(parsed.Names) > 0 {
+		following, err := s.userService.GetFollowingByUser(userId)
+		if err != nil {
+			return entities.QuickAddDraft{}, err
+		}
+
+		for _, name := range parsed.Names {
+			user, ok := matchFollowedUser(following, name)
+			if !ok {
+				draft.UnresolvedNames = append(draft.UnresolvedNames, name)
+				continue
+			}
+			draft.Participants = append(draft.Participants, entities.QuickAddParticipant{
+				UserId: user.Id,
+				Name:   user.Name,
+				Input:  name,
+			})
+		}
+	}
+
+	return draft, nil
+}
+
+// resolveCategory picks up the user's category for the #tag without creating
+// it; ResolveScheduleCategory creates it when the draft is confirmed.
+func (s *quickAddService) resolveCategory(draft *entities.QuickAddDraft) error {
+	if draft.Schedule.Category == "" {
+		return nil
+	}
+
+	categories, err := s.categoryService.GetCategoriesByUser(draft.Schedule.UserId)
+	if err != nil {
+		return err
+	}
+
+	for _, category := range categories {
+		if strings.EqualFold(category.Name, draft.Schedule.Category) {
+			draft.Schedule.CategoryId = &category.Id
+			draft.Schedule.Category = category.Name
+			return nil
+		}
+	}
+
+	draft.NewCategory = true
+	return nil
+}
+
+// matchFollowedUser prefers an exact full name, then a unique first name, then
+// a unique name prefix. Ambiguous names are left for the user to pick.
+func matchFollowedUser(following []entities.User, name string) (entities.User, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	matchers := []func(string) bool{
+		func(full string) bool { return full == name },
+		func(full string) bool { return strings.Fields(full)[0] == name },
+		func(full string) bool { return strings.HasPrefix(full, name) },
+	}
+
+	for _, matches := range matchers {
+		var found []entities.User
+		for _, user := range following {
+			full := strings.ToLower(strings.TrimSpace(user.Name))
+			if full != "" && matches(full) {
+				found = append(found, user)
+			}
+		}
+
+		if len(found) == 1 {
+			return found[0], true
+		}
+		if len(found) > 1 {
+			return entities.User{}, false
+		}
+	}
+
+	return entities.User{}, false
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/mail"
+)
+
+const (
+	ReminderChannelInApp = "InApp"
+	ReminderChannelEmail = "Email"
+)
+
+// ReminderChannel delivers one reminder. Each attempt is claimed by exactly one
+// worker, but a delivery whose worker stopped between sending and recording the
+// result is retried, so implementations should be idempotent for a given
+// delivery Id. now is the wall-clock time of the attempt.
+type ReminderChannel interface {
+	Name() string
+	Deliver(delivery entities.ReminderDelivery, schedule entities.Schedule, user entities.User, now time.Time) error
+}
+
+type inAppReminderChannel struct {
+	notificationService NotificationService
+}
+
+func NewInAppReminderChannel() ReminderChannel {
+	return &inAppReminderChannel{
+		notificationService: NewNotificationService(),
+	}
+}
+
+func (ch *inAppReminderChannel) Name() string {
+	return ReminderChannelInApp
+}
+
+func (ch *inAppReminderChannel) Deliver(delivery entities.ReminderDelivery, schedule entities.Schedule, user entities.User, now time.Time) error {
+	scheduleId := schedule.Id
+
+	// Reusing the delivery Id makes a repeated delivery a no-op.
+	return ch.notificationService.Notify(entities.Notification{
+		Id:         delivery.Id,
+		UserId:     user.Id,
+		ScheduleId: &scheduleId,
+		Type:       "Reminder",
+		Title:      schedule.Title,
+		Message:    reminderMessage(schedule, now),
+	})
+}
+
+type emailReminderChannel struct {
+	sender mail.Sender
+}
+
+func NewEmailReminderChannel() ReminderChannel {
+	return &emailReminderChannel{
+		sender: mail.NewSMTPSender(),
+	}
+}
+
+func (ch *emailReminderChannel) Name() string {
+	return ReminderChannelEmail
+}
+
+// Deliver is at-least-once: SMTP has no idempotency key, so a send that succeeded
+// but was never recorded as Sent is sent again when the delivery goes stale.
+func (ch *emailReminderChannel) Deliver(delivery entities.ReminderDelivery, schedule entities.Schedule, user entities.User, now time.Time) error {
+	body := fmt.Sprintf("Hi %s,\n\n%s\n\nStarts: %s\nEnds: %s\n",
+		user.Name,
+		reminderMessage(schedule, now),
+		utils.FormatScheduleTime(schedule.StartTime),
+		utils.FormatScheduleTime(schedule.EndTime),
+	)
+
+	return ch.sender.Send(user.Email, "Reminder: "+schedule.Title, body)
+}
+
+// reminderMessage says how long until the schedule starts as of now rather than
+// repeating the reminder's offset, which is wrong for a reminder sent late.
+func reminderMessage(schedule entities.Schedule, now time.Time) string {
+	message := fmt.Sprintf("%s starts in %s", schedule.Title, humanizeMinutes(minutesUntil(schedule.StartTime, now)))
+	if schedule.Location != "" {
+		message += " at " + schedule.Location
+	}
+
+	return message
+}
+
+// minutesUntil rounds up, so a reminder sent a few seconds after its fire time
+// still reads "1 hour" rather than "59 minutes".
+func minutesUntil(t time.Time, now time.Time) int {
+	remaining := t.Sub(now)
+	minutes := int(remaining / time.Minute)
+	if remaining%time.Minute > 0 {
+		minutes++
+	}
+	if minutes < 1 {
+		minutes = 1
+	}
+
+	return minutes
+}
+
+// humanizeMinutes names at most the two largest units, e.g. "1 day 3 hours".
+func humanizeMinutes(minutes int) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s", unit)
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+
+	parts := make([]string, 0, 2)
+	for _, unit := range []struct {
+		name    string
+		minutes int
+	}{{"day", 24 * 60}, {"hour", 60}, {"minute", 1}} {
+		if len(parts) == 2 {
+			break
+		}
+		if n := minutes / unit.minutes; n > 0 {
+			parts = append(parts, plural(n, unit.name))
+			minutes -= n * unit.minutes
+		} else if len(parts) > 0 {
+			break
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,305 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidReminder = errors.New("invalid reminder")
+)
+
+const (
+	maxReminderOffsetMinutes = 7 * 24 * 60
+	maxRemindersPerTarget    = 5
+	maxDeliveryAttempts      = 5
+	staleDeliveryAfter       = 5 * time.Minute
+)
+
+// reminderNamespace seeds the deterministic ReminderDelivery ids.
+var reminderNamespace = uuid.MustParse("3f1b8a52-7c1e-4a8e-9d0b-5b6f0c2e9a41")
+
+type ReminderService interface {
+	GetScheduleReminders(userId uuid.UUID, scheduleId uuid.UUID) ([]entities.Reminder, error)
+	SetScheduleReminders(userId uuid.UUID, scheduleId uuid.UUID, reminders []entities.Reminder) error
+	GetDefaultReminders(userId uuid.UUID) ([]entities.Reminder, error)
+	SetDefaultReminders(userId uuid.UUID, reminders []entities.Reminder) error
+	ValidateReminders(userId uuid.UUID, reminders []entities.Reminder) error
+	DispatchDueReminders(now time.Time) (int, error)
+}
+
+type reminderService struct {
+	repo         repositories.ReminderRepository
+	scheduleRepo repositories.ScheduleRepository
+	userRepo     repositories.UserRepository
+	channels     map[string]ReminderChannel
+}
+
+func NewReminderService() ReminderService {
+	channels := map[string]ReminderChannel{}
+	for _, channel := range []ReminderChannel{NewInAppReminderChannel(), NewEmailReminderChannel()} {
+		channels[channel.Name()] = channel
+	}
+
+	return &reminderService{
+		repo:         repositories.NewReminderRepository(),
+		scheduleRepo: repositories.NewScheduleRepository(),
+		userRepo:     repositories.NewUserRepository(),
+		channels:     channels,
+	}
+}
+
+func (s *reminderService) GetScheduleReminders(userId uuid.UUID, scheduleId uuid.UUID) ([]entities.Reminder, error) {
+	reminders, err := s.repo.GetScheduleReminders(userId, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (s *reminderService) SetScheduleReminders(userId uuid.UUID, scheduleId uuid.UUID, reminders []entities.Reminder) error {
+	prepared, err := s.prepareReminders(userId, &scheduleId, reminders)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.ReplaceScheduleReminders(userId, scheduleId, prepared)
+}
+
+func (s *reminderService) GetDefaultReminders(userId uuid.UUID) ([]entities.Reminder, error) {
+	reminders, err := s.repo.GetDefaultReminders(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (s *reminderService) SetDefaultReminders(userId uuid.UUID, reminders []entities.Reminder) error {
+	prepared, err := s.prepareReminders(userId, nil, reminders)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.ReplaceDefaultReminders(userId, prepared)
+}
+
+// ValidateReminders checks reminders that will be set later, e.g. from a template.
+func (s *reminderService) ValidateReminders(userId uuid.UUID, reminders []entities.Reminder) error {
+	_, err := s.prepareReminders(userId, nil, reminders)
+	return err
+}
+
+func (s *reminderService) prepareReminders(userId uuid.UUID, scheduleId *uuid.UUID, reminders []entities.Reminder) ([]entities.Reminder, error) {
+	if len(reminders) > maxRemindersPerTarget {
+		return nil, fmt.Errorf("%w: at most %d reminders", ErrInvalidReminder, maxRemindersPerTarget)
+	}
+
+	prepared := make([]entities.Reminder, 0, len(reminders))
+	for _, reminder := range reminders {
+		if reminder.Channel == "" {
+			reminder.Channel = ReminderChannelInApp
+		}
+		if _, ok := s.channels[reminder.Channel]; !ok {
+			return nil, fmt.Errorf("%w: unknown channel %q", ErrInvalidReminder, reminder.Channel)
+		}
+		if reminder.OffsetMinutes <= 0 || reminder.OffsetMinutes > maxReminderOffsetMinutes {
+			return nil, fmt.Errorf("%w: offset must be between 1 and %d minutes", ErrInvalidReminder, maxReminderOffsetMinutes)
+		}
+
+		reminder.Id = uuid.New()
+		reminder.UserId = userId
+		reminder.ScheduleId = scheduleId
+		prepared = append(prepared, reminder)
+	}
+
+	return prepared, nil
+}
+
+// DispatchDueReminders sends every reminder whose fire time has passed for events
+// that have not started yet. Reminders missed while the server was down are still
+// sent as long as the event is upcoming. It returns the number delivered.
+func (s *reminderService) DispatchDueReminders(now time.Time) (int, error) {
+	sent := s.retryDeliveries(now)
+
+	schedules, err := s.scheduleRepo.GetSchedulesStartingBetween(now, now.Add(maxReminderOffsetMinutes*time.Minute))
+	if err != nil {
+		return sent, err
+	}
+
+	for _, schedule := range schedules {
+		recipients, err := s.recipients(schedule)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+
+		for _, user := range recipients {
+			reminders, err := s.effectiveReminders(user.Id, schedule.Id)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+
+			for _, reminder := range reminders {
+				fireAt := schedule.StartTime.Add(-time.Duration(reminder.OffsetMinutes) * time.Minute)
+				if fireAt.After(now) {
+					continue
+				}
+
+				delivery := entities.ReminderDelivery{
+					Id:            deliveryId(schedule, user.Id, reminder),
+					ScheduleId:    schedule.Id,
+					UserId:        user.Id,
+					Channel:       reminder.Channel,
+					OffsetMinutes: reminder.OffsetMinutes,
+					FireAt:        fireAt,
+					Status:        "Sending",
+					Attempts:      1,
+					ClaimedAt:     time.Now(),
+				}
+
+				claimed, err := s.repo.ClaimDelivery(delivery)
+				if err != nil {
+					log.Println(err)
+					continue
+				}
+				if !claimed {
+					continue
+				}
+
+				if s.deliver(delivery, schedule, user, now) {
+					sent++
+				}
+			}
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *reminderService) retryDeliveries(now time.Time) int {
+	deliveries, err := s.repo.GetRetryableDeliveries(time.Now().Add(-staleDeliveryAfter), maxDeliveryAttempts)
+	if err != nil {
+		log.Println(err)
+		return 0
+	}
+
+	sent := 0
+	for _, delivery := range deliveries {
+		// Another worker may have picked the row up since it was read; only the
+		// one whose conditional update lands goes on to send it.
+		claimedAt := time.Now()
+		claimed, err := s.repo.ReclaimDelivery(delivery, claimedAt)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		delivery.Status = "Sending"
+		delivery.Attempts++
+		delivery.ClaimedAt = claimedAt
+
+		schedule, err := s.scheduleRepo.FindSchedule(delivery.ScheduleId)
+		if err != nil || !schedule.StartTime.After(now) {
+			delivery.Status = "Expired"
+			if err := s.repo.UpdateDelivery(delivery); err != nil {
+				log.Println(err)
+			}
+			continue
+		}
+
+		user, err := s.userRepo.FindUser(delivery.UserId)
+		if err != nil {
+			delivery.Status = "Failed"
+			delivery.LastError = err.Error()
+			if err := s.repo.UpdateDelivery(delivery); err != nil {
+				log.Println(err)
+			}
+			continue
+		}
+
+		if s.deliver(delivery, schedule, user, now) {
+			sent++
+		}
+	}
+
+	return sent
+}
+
+// deliver sends a delivery the caller has already claimed and records the result.
+func (s *reminderService) deliver(delivery entities.ReminderDelivery, schedule entities.Schedule, user entities.User, now time.Time) bool {
+	err := errors.New("channel not available")
+	if channel, ok := s.channels[delivery.Channel]; ok {
+		err = channel.Deliver(delivery, schedule, user, now)
+	}
+
+	if err != nil {
+		delivery.Status = "Failed"
+		delivery.LastError = err.Error()
+	} else {
+		sentAt := time.Now()
+		delivery.Status = "Sent"
+		delivery.SentAt = &sentAt
+		delivery.LastError = ""
+	}
+
+	if err := s.repo.UpdateDelivery(delivery); err != nil {
+		log.Println(err)
+	}
+
+	return delivery.Status == "Sent"
+}
+
+// recipients are the owner and every participant who accepted.
+func (s *reminderService) recipients(schedule entities.Schedule) ([]entities.User, error) {
+	owner, err := s.userRepo.FindUser(schedule.UserId)
+	if err != nil {
+		return nil, err
+	}
+	recipients := []entities.User{owner}
+
+	participants, err := s.scheduleRepo.GetAllAcceptedSchedulesBySchedule(schedule.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, participant := range participants {
+		user, err := s.userRepo.FindUser(participant.UserId)
+		if err != nil {
+			continue
+		}
+		recipients = append(recipients, user)
+	}
+
+	return recipients, nil
+}
+
+// effectiveReminders prefers the user's reminders for this schedule and falls
+// back to their defaults.
+func (s *reminderService) effectiveReminders(userId uuid.UUID, scheduleId uuid.UUID) ([]entities.Reminder, error) {
+	reminders, err := s.repo.GetScheduleReminders(userId, scheduleId)
+	if err != nil {
+		return nil, err
+	}
+	if len(reminders) > 0 {
+		return reminders, nil
+	}
+
+	return s.repo.GetDefaultReminders(userId)
+}
+
+// deliveryId includes the start time so moving an event re-arms its reminders.
+func deliveryId(schedule entities.Schedule, userId uuid.UUID, reminder entities.Reminder) uuid.UUID {
+	key := fmt.Sprintf("%s|%s|%d|%s|%d", schedule.Id, userId, reminder.OffsetMinutes, reminder.Channel, schedule.StartTime.Unix())
+	return uuid.NewSHA1(reminderNamespace, []byte(key))
+}
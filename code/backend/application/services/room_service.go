@@ -0,0 +1,169 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidRoom      = errors.New("invalid room")
+	ErrRoomNotFound     = errors.New("room not found")
+	ErrDuplicateRoom    = errors.New("room already exists")
+	ErrInvalidRoomQuery = errors.New("invalid room query")
+	ErrRoomUnavailable  = repositories.ErrRoomUnavailable
+)
+
+type RoomService interface {
+	CreateNewRoom(Room entities.Room) (entities.Room, error)
+	GetRoomByID(id uuid.UUID) (entities.Room, error)
+	GetAllRooms() ([]entities.Room, error)
+	UpdateRoom(Room entities.Room) error
+	DeleteRoom(id uuid.UUID) error
+	SearchAvailableRooms(query entities.RoomQuery) ([]entities.Room, error)
+	ResolveScheduleRoom(Schedule *entities.Schedule) error
+}
+
+type roomService struct {
+	repo repositories.RoomRepository
+}
+
+func NewRoomService() RoomService {
+	return &roomService{
+		repo: repositories.NewRoomRepository(),
+	}
+}
+
+func (s *roomService) CreateNewRoom(Room entities.Room) (entities.Room, error) {
+	Room.Id = uuid.New()
+	if err := s.validate(Room); err != nil {
+		return entities.Room{}, err
+	}
+
+	if err := s.repo.CreateNewRoom(Room); err != nil {
+		return entities.Room{}, err
+	}
+
+	return Room, nil
+}
+
+func (s *roomService) GetRoomByID(id uuid.UUID) (entities.Room, error) {
+	room, err := s.repo.FindRoom(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.Room{}, ErrRoomNotFound
+	}
+	if err != nil {
+		return entities.Room{}, err
+	}
+
+	return room, nil
+}
+
+func (s *roomService) GetAllRooms() ([]entities.Room, error) {
+	rooms, err := s.repo.GetAllRooms()
+	if err != nil {
+		return nil, err
+	}
+
+	return rooms, nil
+}
+
+func (s *roomService) UpdateRoom(Room entities.Room) error {
+	if _, err := s.GetRoomByID(Room.Id); err != nil {
+		return err
+	}
+
+	if err := s.validate(Room); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateRoom(Room)
+}
+
+func (s *roomService) DeleteRoom(id uuid.UUID) error {
+	if _, err := s.GetRoomByID(id); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteRoom(id)
+}
+
+// SearchAvailableRooms returns free rooms that have at least MinCapacity seats
+// and every requested feature, smallest rooms first.
+func (s *roomService) SearchAvailableRooms(query entities.RoomQuery) ([]entities.Room, error) {
+	if !query.EndTime.After(query.StartTime) || query.MinCapacity < 0 {
+		return nil, ErrInvalidRoomQuery
+	}
+
+	rooms, err := s.repo.GetAvailableRooms(query.StartTime, query.EndTime, query.MinCapacity, query.Building)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]entities.Room, 0, len(rooms))
+	for _, room := range rooms {
+		if hasFeatures(room, query.Features) {
+			available = append(available, room)
+		}
+	}
+
+	return available, nil
+}
+
+// ResolveScheduleRoom checks that a referenced room exists and copies its name
+// into Location, so clients that only read the location keep working.
+// Double-booking itself is checked by the repository inside the write transaction.
+func (s *roomService) ResolveScheduleRoom(Schedule *entities.Schedule) error {
+	if Schedule.RoomId == nil {
+		return nil
+	}
+
+	room, err := s.GetRoomByID(*Schedule.RoomId)
+	if err != nil {
+		return err
+	}
+
+	Schedule.Location = room.Name
+	return nil
+}
+
+func (s *roomService) validate(Room entities.Room) error {
+	if strings.TrimSpace(Room.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidRoom)
+	}
+	if Room.Capacity <= 0 {
+		return fmt.Errorf("%w: capacity must be positive", ErrInvalidRoom)
+	}
+
+	existing, err := s.repo.FindRoomByName(strings.TrimSpace(Room.Name))
+	if err == nil && existing.Id != Room.Id {
+		return ErrDuplicateRoom
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+func hasFeatures(Room entities.Room, features []string) bool {
+	for _, feature := range features {
+		found := false
+		for _, roomFeature := range Room.Features {
+			if strings.EqualFold(roomFeature, feature) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidScheduleCopy = errors.New("invalid schedule copy")
+)
+
+type ScheduleCopyService interface {
+	CopySchedules(request entities.ScheduleCopyRequest) (entities.ScheduleCopyResult, error)
+}
+
+type scheduleCopyService struct {
+	repo      repositories.ScheduleRepository
+	userRepo  repositories.UserRepository
+	schedules *scheduleService
+}
+
+func NewScheduleCopyService() ScheduleCopyService {
+	schedules := newScheduleService()
+	return &scheduleCopyService{
+		repo:      schedules.repo,
+		userRepo:  schedules.userRepo,
+		schedules: schedules,
+	}
+}
+
+// CopySchedules duplicates schedules the user can read into new schedules the
+// user owns. When the user may edit a source, its invitees are invited again
+// as Pending, except those who rejected it; the recipient, if any, is invited
+// too and notified. The copies are created in one transaction, so either every
+// copy is made or none is.
+func (s *scheduleCopyService) CopySchedules(request entities.ScheduleCopyRequest) (entities.ScheduleCopyResult, error) {
+	if err := s.validate(request); err != nil {
+		return entities.ScheduleCopyResult{}, err
+	}
+
+	result := entities.ScheduleCopyResult{
+		Copies: make([]entities.ScheduleCopy, 0, len(request.ScheduleIds)),
+	}
+
+	err := s.repo.WithTransaction(func(tx repositories.ScheduleRepository) error {
+		seen := make(map[uuid.UUID]bool, len(request.ScheduleIds))
+		for _, id := range request.ScheduleIds {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			copied, err := s.copyOne(tx, request, id)
+			if err != nil {
+				return fmt.Errorf("schedule %s: %w", id, err)
+			}
+			result.Copies = append(result.Copies, copied)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entities.ScheduleCopyResult{}, err
+	}
+
+	for i := range result.Copies {
+		copied := &result.Copies[i].Schedule
+		s.schedules.revisionService.Record(entities.RevisionCreated, request.UserId, nil, copied)
+		if request.RecipientId != nil {
+			s.notifyRecipient(*request.RecipientId, *copied)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *scheduleCopyService) copyOne(tx repositories.ScheduleRepository, request entities.ScheduleCopyRequest, id uuid.UUID) (entities.ScheduleCopy, error) {
+	source, err := s.schedules.AuthorizeSchedule(id, request.UserId, SchedulePermissionRead)
+	if err != nil {
+		return entities.ScheduleCopy{}, err
+	}
+
+	offsetDays, offset := 0, request.Offset
+	if request.TargetWeek != nil {
+		offsetDays = int(weekStart(*request.TargetWeek, time.UTC).Sub(weekStart(source.StartTime, time.UTC)).Hours() / 24)
+		offset = 0
+	}
+	if source.IsAllDay && offset%(24*time.Hour) != 0 {
+		return entities.ScheduleCopy{}, fmt.Errorf("%w: all-day schedules can only move by whole days", ErrInvalidScheduleCopy)
+	}
+
+	copied := source
+	copied.Id = uuid.New()
+	copied.UserId = request.UserId
+	copied.StartTime = source.StartTime.AddDate(0, 0, offsetDays).Add(offset)
+	copied.EndTime = source.EndTime.AddDate(0, 0, offsetDays).Add(offset)
+	copied.DeletedAt = gorm.DeletedAt{}
+	copied.Version = 1
+	if source.UserId != request.UserId {
+		// The category belongs to the source's owner; the name is looked up
+		// again among the user's own categories.
+		copied.CategoryId = nil
+	}
+
+	if err := s.schedules.prepare(&copied); err != nil {
+		return entities.ScheduleCopy{}, err
+	}
+
+	participantIds, err := s.participantIds(source, request)
+	if err != nil {
+		return entities.ScheduleCopy{}, err
+	}
+
+	participants := make([]entities.ScheduleParticipant, 0, len(participantIds))
+	for _, participantId := range participantIds {
+		participants = append(participants, entities.ScheduleParticipant{
+			Id:         uuid.New(),
+			ScheduleId: copied.Id,
+			UserId:     participantId,
+			Status:     "Pending",
+		})
+	}
+
+	if err := tx.CreateScheduleWithParticipants(copied, participants); err != nil {
+		return entities.ScheduleCopy{}, err
+	}
+
+	return entities.ScheduleCopy{SourceId: source.Id, Schedule: copied, ParticipantIds: participantIds}, nil
+}
+
+// participantIds keeps the source's invitee list only for users who may edit
+// the source, so copying a public schedule does not invite strangers.
+func (s *scheduleCopyService) participantIds(source entities.Schedule, request entities.ScheduleCopyRequest) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0)
+	seen := map[uuid.UUID]bool{request.UserId: true}
+
+	if s.schedules.authorize(source, request.UserId, SchedulePermissionEdit) == nil {
+		participants, err := s.repo.GetAllScheduleRequestsBySchedule(source.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		if source.UserId != request.UserId {
+			seen[source.UserId] = true
+			ids = append(ids, source.UserId)
+		}
+		for _, participant := range participants {
+			if participant.Status == "Rejected" || seen[participant.UserId] {
+				continue
+			}
+			seen[participant.UserId] = true
+			ids = append(ids, participant.UserId)
+		}
+	}
+
+	if request.RecipientId != nil && !seen[*request.RecipientId] {
+		ids = append(ids, *request.RecipientId)
+	}
+
+	return ids, nil
+}
+
+func (s *scheduleCopyService) notifyRecipient(recipientId uuid.UUID, copied entities.Schedule) {
+	sender, err := s.userRepo.FindUser(copied.UserId)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	scheduleId := copied.Id
+	if err := s.schedules.notificationService.Notify(entities.Notification{
+		UserId:     recipientId,
+		ScheduleId: &scheduleId,
+		Type:       "ScheduleShared",
+		Title:      copied.Title,
+		Message:    fmt.Sprintf("%s invited you to %s on %s", sender.Name, copied.Title, utils.FormatScheduleTime(copied.StartTime)),
+	}); err != nil {
+		log.Println(err)
+	}
+}
+
+func (s *scheduleCopyService) validate(request entities.ScheduleCopyRequest) error {
+	if request.UserId == uuid.Nil {
+		return fmt.Errorf("%w: userId is required", ErrInvalidScheduleCopy)
+	}
+	if len(request.ScheduleIds) == 0 {
+		return fmt.Errorf("%w: scheduleIds is required", ErrInvalidScheduleCopy)
+	}
+	if len(request.ScheduleIds) > bulkMaxSchedules {
+		return fmt.Errorf("%w: at most %d schedules at once", ErrInvalidScheduleCopy, bulkMaxSchedules)
+	}
+	if request.TargetWeek != nil && request.Offset != 0 {
+		return fmt.Errorf("%w: give either an offset or a target week", ErrInvalidScheduleCopy)
+	}
+
+	if request.RecipientId != nil {
+		if *request.RecipientId == request.UserId {
+			return fmt.Errorf("%w: recipient must be another user", ErrInvalidScheduleCopy)
+		}
+		if _, err := s.userRepo.FindUser(*request.RecipientId); errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: recipient not found", ErrInvalidScheduleCopy)
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
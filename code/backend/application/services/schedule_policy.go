@@ -0,0 +1,130 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// What a user may do with a schedule. The owner may do everything; accepted or
+// pending co-organizers may edit; invited participants may read, as may anyone
+// when the schedule is public.
+const (
+	SchedulePermissionRead   = "read"
+	SchedulePermissionEdit   = "edit"
+	SchedulePermissionDelete = "delete"
+	SchedulePermissionManage = "manage"
+)
+
+var (
+	ErrScheduleForbidden   = errors.New("not allowed to access this schedule")
+	ErrParticipantNotFound = errors.New("participant not found")
+)
+
+func forbidden(reason string) error {
+	return fmt.Errorf("%w: %s", ErrScheduleForbidden, reason)
+}
+
+// AuthorizeSchedule loads a schedule and checks that userId holds permission
+// on it. Violations wrap ErrScheduleForbidden with the reason.
+func (s *scheduleService) AuthorizeSchedule(id uuid.UUID, userId uuid.UUID, permission string) (entities.Schedule, error) {
+	schedule, err := s.repo.FindSchedule(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.Schedule{}, ErrScheduleNotFound
+	}
+	if err != nil {
+		return entities.Schedule{}, err
+	}
+
+	if err := s.authorize(schedule, userId, permission); err != nil {
+		return entities.Schedule{}, err
+	}
+
+	return schedule, nil
+}
+
+func (s *scheduleService) authorize(schedule entities.Schedule, userId uuid.UUID, permission string) error {
+	if schedule.UserId == userId {
+		return nil
+	}
+
+	switch permission {
+	case SchedulePermissionDelete:
+		return forbidden("only the owner can delete this schedule")
+	case SchedulePermissionManage:
+		return forbidden("only the owner can manage co-organizers")
+	}
+
+	participants, err := s.repo.GetAllScheduleRequestsBySchedule(schedule.Id)
+	if err != nil {
+		return err
+	}
+
+	var participant *entities.ScheduleParticipant
+	for i := range participants {
+		if participants[i].UserId == userId {
+			participant = &participants[i]
+			break
+		}
+	}
+
+	switch permission {
+	case SchedulePermissionRead:
+		if participant != nil || schedule.Visibility == entities.VisibilityPublic {
+			return nil
+		}
+		return forbidden("only the owner and invited participants can view this schedule")
+	case SchedulePermissionEdit:
+		if participant != nil && participant.Role == entities.ParticipantRoleCoOrganizer && participant.Status != "Rejected" {
+			return nil
+		}
+		return forbidden("only the owner or a co-organizer can edit this schedule")
+	default:
+		return forbidden("unknown permission " + permission)
+	}
+}
+
+// respond changes the status of an invitation, which only the invited user may do.
+func (s *scheduleService) respond(participantId uuid.UUID, userId uuid.UUID, status string) error {
+	participant, err := s.repo.FindScheduleParticipant(participantId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrParticipantNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if participant.UserId != userId {
+		return forbidden("only the invited user can respond to this invitation")
+	}
+
+	if status == "Accepted" {
+		return s.repo.AcceptSchedule(participantId)
+	}
+
+	return s.repo.RejectSchedule(participantId)
+}
+
+// SetCoOrganizer lets the owner grant or revoke a participant's right to edit.
+func (s *scheduleService) SetCoOrganizer(scheduleId uuid.UUID, userId uuid.UUID, participantUserId uuid.UUID, coOrganizer bool) error {
+	if _, err := s.AuthorizeSchedule(scheduleId, userId, SchedulePermissionManage); err != nil {
+		return err
+	}
+
+	role := entities.ParticipantRoleAttendee
+	if coOrganizer {
+		role = entities.ParticipantRoleCoOrganizer
+	}
+
+	updated, err := s.repo.SetParticipantRole(scheduleId, participantUserId, role)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrParticipantNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const maxProposalNoteLength = 500
+
+var (
+	ErrInvalidProposal  = errors.New("invalid proposal")
+	ErrProposalNotFound = errors.New("proposal not found")
+	ErrProposalClosed   = repositories.ErrProposalNotPending
+)
+
+type ScheduleProposalService interface {
+	ProposeTime(participantId uuid.UUID, userId uuid.UUID, startTime time.Time, endTime time.Time, note string) (entities.ScheduleProposal, error)
+	GetProposalsBySchedule(scheduleId uuid.UUID, userId uuid.UUID) ([]entities.ScheduleProposal, error)
+	AcceptProposal(id uuid.UUID, userId uuid.UUID) (entities.Schedule, error)
+	DeclineProposal(id uuid.UUID, userId uuid.UUID) error
+}
+
+type scheduleProposalService struct {
+	repo      repositories.ScheduleProposalRepository
+	schedules *scheduleService
+}
+
+func NewScheduleProposalService() ScheduleProposalService {
+	return &scheduleProposalService{
+		repo:      repositories.NewScheduleProposalRepository(),
+		schedules: newScheduleService(),
+	}
+}
+
+// ProposeTime lets the invitee of participant row participantId suggest
+// another time. Their invitation status is left as it is, and the owner is
+// told about the proposal.
+func (s *scheduleProposalService) ProposeTime(participantId uuid.UUID, userId uuid.UUID, startTime time.Time, endTime time.Time, note string) (entities.ScheduleProposal, error) {
+	participant, err := s.schedules.repo.FindScheduleParticipant(participantId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.ScheduleProposal{}, ErrParticipantNotFound
+	}
+	if err != nil {
+		return entities.ScheduleProposal{}, err
+	}
+	if participant.UserId != userId {
+		return entities.ScheduleProposal{}, forbidden("only the invited user can propose a time")
+	}
+
+	schedule, err := s.schedules.GetScheduleForUser(participant.ScheduleId, userId)
+	if err != nil {
+		return entities.ScheduleProposal{}, err
+	}
+
+	// All-day proposals are read the same way the schedule itself would be.
+	probe := entities.Schedule{StartTime: startTime, EndTime: endTime, IsAllDay: schedule.IsAllDay}
+	normalizeAllDay(&probe)
+	note = strings.TrimSpace(note)
+
+	if !probe.EndTime.After(probe.StartTime) {
+		return entities.ScheduleProposal{}, fmt.Errorf("%w: end time must be after start time", ErrInvalidProposal)
+	}
+	if probe.StartTime.Equal(schedule.StartTime) && probe.EndTime.Equal(schedule.EndTime) {
+		return entities.ScheduleProposal{}, fmt.Errorf("%w: the schedule is already at that time", ErrInvalidProposal)
+	}
+	if len(note) > maxProposalNoteLength {
+		return entities.ScheduleProposal{}, fmt.Errorf("%w: note is limited to %d characters", ErrInvalidProposal, maxProposalNoteLength)
+	}
+
+	proposal := entities.ScheduleProposal{
+		Id:            uuid.New(),
+		ScheduleId:    schedule.Id,
+		ParticipantId: participant.Id,
+		UserId:        userId,
+		StartTime:     probe.StartTime,
+		EndTime:       probe.EndTime,
+		Note:          note,
+		Status:        entities.ProposalPending,
+	}
+	if err := s.repo.CreateNewScheduleProposal(proposal); err != nil {
+		return entities.ScheduleProposal{}, err
+	}
+
+	s.notify(schedule.UserId, schedule, "ScheduleTimeProposed",
+		fmt.Sprintf("%s was proposed for %s", utils.FormatScheduleTime(proposal.StartTime), schedule.Title))
+
+	return proposal, nil
+}
+
+// GetProposalsBySchedule shows users who may edit the schedule every proposal,
+// and other participants only their own.
+func (s *scheduleProposalService) GetProposalsBySchedule(scheduleId uuid.UUID, userId uuid.UUID) ([]entities.ScheduleProposal, error) {
+	schedule, err := s.schedules.AuthorizeSchedule(scheduleId, userId, SchedulePermissionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	proposals, err := s.repo.GetScheduleProposalsBySchedule(scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.schedules.authorize(schedule, userId, SchedulePermissionEdit) == nil {
+		return proposals, nil
+	}
+
+	own := make([]entities.ScheduleProposal, 0)
+	for _, proposal := range proposals {
+		if proposal.UserId == userId {
+			own = append(own, proposal)
+		}
+	}
+
+	return own, nil
+}
+
+// AcceptProposal reschedules to the proposed time. The proposer is counted as
+// accepted, every other participant has to answer again, and the remaining
+// pending proposals are superseded.
+func (s *scheduleProposalService) AcceptProposal(id uuid.UUID, userId uuid.UUID) (entities.Schedule, error) {
+	proposal, schedule, err := s.findForEditor(id, userId)
+	if err != nil {
+		return entities.Schedule{}, err
+	}
+
+	before := schedule
+	schedule.StartTime = proposal.StartTime
+	schedule.EndTime = proposal.EndTime
+	if err := s.schedules.prepare(&schedule); err != nil {
+		return entities.Schedule{}, err
+	}
+
+	if err := s.repo.AcceptScheduleProposal(proposal, schedule); err != nil {
+		return entities.Schedule{}, err
+	}
+
+	after, err := s.schedules.GetScheduleByID(schedule.Id)
+	if err != nil {
+		return entities.Schedule{}, err
+	}
+
+	s.schedules.revisionService.Record(entities.RevisionUpdated, userId, &before, &after)
+	notifyParticipants(s.schedules.repo, s.schedules.notificationService, after, "ScheduleRescheduled",
+		fmt.Sprintf("%s moved to %s, please respond again", after.Title, utils.FormatScheduleTime(after.StartTime)))
+
+	return after, nil
+}
+
+func (s *scheduleProposalService) DeclineProposal(id uuid.UUID, userId uuid.UUID) error {
+	proposal, schedule, err := s.findForEditor(id, userId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeclineScheduleProposal(proposal.Id); err != nil {
+		return err
+	}
+
+	s.notify(proposal.UserId, schedule, "ScheduleProposalDeclined",
+		fmt.Sprintf("Your proposed time for %s was declined", schedule.Title))
+	return nil
+}
+
+// findForEditor loads a proposal and its schedule for a user who may edit the
+// schedule, as only they can act on proposals.
+func (s *scheduleProposalService) findForEditor(id uuid.UUID, userId uuid.UUID) (entities.ScheduleProposal, entities.Schedule, error) {
+	proposal, err := s.repo.FindScheduleProposal(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.ScheduleProposal{}, entities.Schedule{}, ErrProposalNotFound
+	}
+	if err != nil {
+		return entities.ScheduleProposal{}, entities.Schedule{}, err
+	}
+
+	schedule, err := s.schedules.AuthorizeSchedule(proposal.ScheduleId, userId, SchedulePermissionEdit)
+	if err != nil {
+		return entities.ScheduleProposal{}, entities.Schedule{}, err
+	}
+	if proposal.Status != entities.ProposalPending {
+		return entities.ScheduleProposal{}, entities.Schedule{}, ErrProposalClosed
+	}
+
+	return proposal, schedule, nil
+}
+
+func (s *scheduleProposalService) notify(userId uuid.UUID, schedule entities.Schedule, kind string, message string) {
+	scheduleId := schedule.Id
+	if err := s.schedules.notificationService.Notify(entities.Notification{
+		UserId:     userId,
+		ScheduleId: &scheduleId,
+		Type:       kind,
+		Title:      schedule.Title,
+		Message:    message,
+	}); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRevisionNotFound = errors.New("revision not found")
+)
+
+type ScheduleRevisionService interface {
+	Record(action string, changedBy uuid.UUID, before *entities.Schedule, after *entities.Schedule)
+	GetRevisionByID(id uuid.UUID) (entities.ScheduleRevision, error)
+	GetRevisionsBySchedule(scheduleId uuid.UUID, userId uuid.UUID) ([]entities.ScheduleRevision, error)
+}
+
+type scheduleRevisionService struct {
+	repo         repositories.ScheduleRevisionRepository
+	scheduleRepo repositories.ScheduleRepository
+}
+
+func NewScheduleRevisionService() ScheduleRevisionService {
+	return &scheduleRevisionService{
+		repo:         repositories.NewScheduleRevisionRepository(),
+		scheduleRepo: repositories.NewScheduleRepository(),
+	}
+}
+
+// Record stores a revision with the field-level diff between before and after.
+// Either side may be nil for a create or a delete. Failures are logged, not
+// returned, because the change itself has already been committed.
+func (s *scheduleRevisionService) Record(action string, changedBy uuid.UUID, before *entities.Schedule, after *entities.Schedule) {
+	var snapshot entities.Schedule
+	switch {
+	case after != nil:
+		snapshot = *after
+	case before != nil:
+		snapshot = *before
+	default:
+		return
+	}
+
+	revision := entities.ScheduleRevision{
+		Id:         uuid.New(),
+		ScheduleId: snapshot.Id,
+		Action:     action,
+		ChangedBy:  changedBy,
+		ChangedAt:  time.Now(),
+		Changes:    diffSchedules(before, after),
+		Snapshot:   snapshot,
+	}
+
+	if err := s.repo.CreateNewScheduleRevision(revision); err != nil {
+		log.Println(err)
+	}
+}
+
+func (s *scheduleRevisionService) GetRevisionByID(id uuid.UUID) (entities.ScheduleRevision, error) {
+	revision, err := s.repo.FindScheduleRevision(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.ScheduleRevision{}, ErrRevisionNotFound
+	}
+	if err != nil {
+		return entities.ScheduleRevision{}, err
+	}
+
+	return revision, nil
+}
+
+// GetRevisionsBySchedule is open to the owner and anyone invited to the schedule.
+func (s *scheduleRevisionService) GetRevisionsBySchedule(scheduleId uuid.UUID, userId uuid.UUID) ([]entities.ScheduleRevision, error) {
+	schedule, err := s.scheduleRepo.FindSchedule(scheduleId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if schedule.UserId != userId {
+		participants, err := s.scheduleRepo.GetAllScheduleRequestsBySchedule(scheduleId)
+		if err != nil {
+			return nil, err
+		}
+
+		invited := false
+		for _, participant := range participants {
+			invited = invited || participant.UserId == userId
+		}
+		if !invited {
+			return nil, ErrScheduleForbidden
+		}
+	}
+
+	revisions, err := s.repo.GetRevisionsBySchedule(scheduleId)
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// diffSchedules lists the user-visible fields that differ. A missing side is
+// treated as "no value", so a create lists every field as set from nil.
+func diffSchedules(before *entities.Schedule, after *entities.Schedule) []entities.FieldChange {
+	fields := func(schedule *entities.Schedule) map[string]interface{} {
+		if schedule == nil {
+			return map[string]interface{}{}
+		}
+
+		return map[string]interface{}{
+			"startTime":   schedule.StartTime,
+			"endTime":     schedule.EndTime,
+			"isAllDay":    schedule.IsAllDay,
+			"title":       schedule.Title,
+			"description": schedule.Description,
+			"location":    schedule.Location,
+			"category":    schedule.Category,
+			"categoryId":  schedule.CategoryId,
+			"roomId":      schedule.RoomId,
+			"visibility":  schedule.Visibility,
+		}
+	}
+
+	order := []string{"startTime", "endTime", "isAllDay", "title", "description", "location", "category", "categoryId", "roomId", "visibility"}
+	from, to := fields(before), fields(after)
+
+	changes := make([]entities.FieldChange, 0)
+	for _, field := range order {
+		if !sameFieldValue(from[field], to[field]) {
+			changes = append(changes, entities.FieldChange{Field: field, From: from[field], To: to[field]})
+		}
+	}
+
+	return changes
+}
+
+func sameFieldValue(a interface{}, b interface{}) bool {
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	if ap, ok := a.(*uuid.UUID); ok {
+		bp, ok := b.(*uuid.UUID)
+		return ok && (ap == nil) == (bp == nil) && (ap == nil || *ap == *bp)
+	}
+
+	return a == b
+}
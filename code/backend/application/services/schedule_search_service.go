@@ -0,0 +1,101 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSearchQuery = errors.New("invalid search query")
+)
+
+const (
+	defaultSearchLimit   = 20
+	maxSearchLimit       = 100
+	maxSearchTerms       = 10
+	searchSnippetRunes   = 160
+	maxSearchQueryLength = 200
+)
+
+type ScheduleSearchService interface {
+	SearchSchedules(query entities.ScheduleSearchQuery) (entities.ScheduleSearchResult, error)
+}
+
+type scheduleSearchService struct {
+	repo repositories.ScheduleSearchRepository
+}
+
+func NewScheduleSearchService() ScheduleSearchService {
+	return &scheduleSearchService{
+		repo: repositories.NewScheduleSearchRepository(),
+	}
+}
+
+// SearchSchedules finds the user's own and accepted schedules whose title,
+// description or location contain every word of the query, each word matching
+// as a prefix, and marks where the words occur.
+func (s *scheduleSearchService) SearchSchedules(query entities.ScheduleSearchQuery) (entities.ScheduleSearchResult, error) {
+	if query.UserId == uuid.Nil {
+		return entities.ScheduleSearchResult{}, fmt.Errorf("%w: userId is required", ErrInvalidSearchQuery)
+	}
+	if len(query.Query) > maxSearchQueryLength {
+		return entities.ScheduleSearchResult{}, fmt.Errorf("%w: at most %d characters", ErrInvalidSearchQuery, maxSearchQueryLength)
+	}
+
+	terms := utils.SearchTerms(query.Query)
+	if len(terms) == 0 {
+		return entities.ScheduleSearchResult{}, fmt.Errorf("%w: query has no words", ErrInvalidSearchQuery)
+	}
+	if len(terms) > maxSearchTerms {
+		return entities.ScheduleSearchResult{}, fmt.Errorf("%w: at most %d words", ErrInvalidSearchQuery, maxSearchTerms)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	hits, err := s.repo.SearchSchedules(query.UserId, terms, limit)
+	if err != nil {
+		return entities.ScheduleSearchResult{}, err
+	}
+
+	for i := range hits {
+		hits[i].Highlights = highlightSchedule(hits[i].Schedule, terms)
+	}
+
+	return entities.ScheduleSearchResult{
+		Query: query.Query,
+		Terms: terms,
+		Hits:  hits,
+	}, nil
+}
+
+func highlightSchedule(schedule entities.Schedule, terms []string) []entities.SearchHighlight {
+	highlights := make([]entities.SearchHighlight, 0, 3)
+
+	fields := []struct {
+		name     string
+		text     string
+		maxRunes int
+	}{
+		{"title", schedule.Title, 0},
+		{"location", schedule.Location, 0},
+		{"description", schedule.Description, searchSnippetRunes},
+	}
+	for _, field := range fields {
+		if highlight, ok := utils.Highlight(field.name, field.text, terms, field.maxRunes); ok {
+			highlights = append(highlights, highlight)
+		}
+	}
+
+	return highlights
+}
@@ -1,53 +1,98 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
 	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
 	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidVisibility = errors.New("invalid visibility")
+	ErrScheduleNotFound  = errors.New("schedule not found")
+	ErrVersionConflict   = repositories.ErrVersionConflict
+)
+
 type ScheduleService interface {
 	CreateNewSchedule(Schedule entities.Schedule) error
 	BatchCreateNewSchedule(Schedules []entities.Schedule) error
 	BatchAddParticipantsToSchedule(participants []entities.ScheduleParticipant) error
 	GetScheduleByID(id uuid.UUID) (entities.Schedule, error)
+	GetScheduleForUser(id uuid.UUID, userId uuid.UUID) (entities.Schedule, error)
 	GetAllSchedules(userID string) ([]entities.Schedule, error)
-	UpdateSchedule(Schedule entities.Schedule) error
-	DeleteSchedule(id uuid.UUID) error
-	AcceptSchedule(id uuid.UUID) error
-	RejectSchedule(id uuid.UUID) error
+	UpdateSchedule(userId uuid.UUID, Schedule entities.Schedule) error
+	PatchSchedule(id uuid.UUID, userId uuid.UUID, patch MergePatch, version int) (entities.Schedule, error)
+	DeleteSchedule(id uuid.UUID, userId uuid.UUID) error
+	AcceptSchedule(id uuid.UUID, userId uuid.UUID) error
+	RejectSchedule(id uuid.UUID, userId uuid.UUID) error
 	GetAllScheduleRequestsByUser(userID uuid.UUID) ([]entities.ScheduleParticipantResponse, error)
-	GetAllScheduleRequestsBySchedule(scheduleID uuid.UUID) ([]entities.ScheduleParticipantResponse, error)
-	GetAllAcceptedSchedulesBySchedule(scheduleID uuid.UUID) ([]entities.ScheduleParticipantResponse, error)
+	GetAllScheduleRequestsBySchedule(scheduleID uuid.UUID, userId uuid.UUID) ([]entities.ScheduleParticipantResponse, error)
+	GetAllAcceptedSchedulesBySchedule(scheduleID uuid.UUID, userId uuid.UUID) ([]entities.ScheduleParticipantResponse, error)
+	CreateScheduleWithParticipants(Schedule entities.Schedule, participantIds []uuid.UUID) error
+	GetFriendSchedules(viewerId uuid.UUID, ownerId uuid.UUID) ([]entities.Schedule, error)
+	RevertSchedule(revisionId uuid.UUID, userId uuid.UUID) (entities.Schedule, error)
+	AuthorizeSchedule(id uuid.UUID, userId uuid.UUID, permission string) (entities.Schedule, error)
+	SetCoOrganizer(scheduleId uuid.UUID, userId uuid.UUID, participantUserId uuid.UUID, coOrganizer bool) error
 }
 
 type scheduleService struct {
-	repo     repositories.ScheduleRepository
-	userRepo repositories.UserRepository
+	repo                repositories.ScheduleRepository
+	userRepo            repositories.UserRepository
+	followRepo          repositories.FollowRepository
+	categoryService     CategoryService
+	roomService         RoomService
+	notificationService NotificationService
+	revisionService     ScheduleRevisionService
 }
 
 func NewScheduleService() ScheduleService {
+	return newScheduleService()
+}
+
+func newScheduleService() *scheduleService {
 	return &scheduleService{
-		repo:     repositories.NewScheduleRepository(),
-		userRepo: repositories.NewUserRepository(),
+		repo:                repositories.NewScheduleRepository(),
+		userRepo:            repositories.NewUserRepository(),
+		followRepo:          repositories.NewFollowRepository(),
+		categoryService:     NewCategoryService(),
+		roomService:         NewRoomService(),
+		notificationService: NewNotificationService(),
+		revisionService:     NewScheduleRevisionService(),
 	}
 }
 
 func (s *scheduleService) CreateNewSchedule(Schedule entities.Schedule) error {
+	if err := s.prepare(&Schedule); err != nil {
+		return err
+	}
+
 	err := s.repo.CreateNewSchedule(Schedule)
 	if err != nil {
 		return err
 	}
 
+	s.revisionService.Record(entities.RevisionCreated, Schedule.UserId, nil, &Schedule)
 	return nil
 }
 
 func (s *scheduleService) BatchCreateNewSchedule(Schedules []entities.Schedule) error {
+	for i := range Schedules {
+		if err := s.prepare(&Schedules[i]); err != nil {
+			return err
+		}
+	}
+
 	err := s.repo.BatchCreateNewSchedule(Schedules)
 	if err != nil {
 		return err
 	}
 
+	for i := range Schedules {
+		s.revisionService.Record(entities.RevisionCreated, Schedules[i].UserId, nil, &Schedules[i])
+	}
 	return nil
 }
 
@@ -69,6 +114,11 @@ func (s *scheduleService) GetScheduleByID(id uuid.UUID) (entities.Schedule, erro
 	return schedule, nil
 }
 
+// GetScheduleForUser returns a schedule the user is allowed to read.
+func (s *scheduleService) GetScheduleForUser(id uuid.UUID, userId uuid.UUID) (entities.Schedule, error) {
+	return s.AuthorizeSchedule(id, userId, SchedulePermissionRead)
+}
+
 func (s *scheduleService) GetAllSchedules(userID string) ([]entities.Schedule, error) {
 	schedules, err := s.repo.GetAllSchedules(userID)
 	if err != nil {
@@ -78,42 +128,195 @@ func (s *scheduleService) GetAllSchedules(userID string) ([]entities.Schedule, e
 	return schedules, nil
 }
 
-func (s *scheduleService) UpdateSchedule(Schedule entities.Schedule) error {
-	err := s.repo.UpdateSchedule(Schedule)
+// UpdateSchedule only applies when Schedule.Version still matches the stored
+// version; a zero version skips the check for clients that don't track it.
+// The owner can never be changed through an update, and only the owner or a
+// co-organizer may update.
+func (s *scheduleService) UpdateSchedule(userId uuid.UUID, Schedule entities.Schedule) error {
+	// Trashed schedules must be restored before they can be edited.
+	existing, err := s.AuthorizeSchedule(Schedule.Id, userId, SchedulePermissionEdit)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	if Schedule.Version == 0 {
+		Schedule.Version = existing.Version
+	}
+	if Schedule.Version != existing.Version {
+		return ErrVersionConflict
+	}
+	Schedule.UserId = existing.UserId
+
+	// Older clients and CalDAV don't send a visibility, so keep the stored one.
+	if Schedule.Visibility == "" {
+		Schedule.Visibility = existing.Visibility
+	}
+
+	return s.update(Schedule, existing, entities.RevisionUpdated, userId)
 }
 
-func (s *scheduleService) DeleteSchedule(id uuid.UUID) error {
-	err := s.repo.DeleteSchedule(id)
+// PatchSchedule applies a merge patch on top of the stored schedule and saves it
+// through UpdateSchedule. id, userId and deletedAt are ignored; version is the
+// expected version unless one is passed in. Every other member is validated and
+// all rejected members are reported together as FieldErrors.
+func (s *scheduleService) PatchSchedule(id uuid.UUID, userId uuid.UUID, patch MergePatch, version int) (entities.Schedule, error) {
+	existing, err := s.AuthorizeSchedule(id, userId, SchedulePermissionEdit)
 	if err != nil {
-		return err
+		return entities.Schedule{}, err
 	}
 
-	return nil
+	Schedule := existing
+	fieldErrors := FieldErrors{}
+	for field, raw := range patch {
+		var err error
+		switch field {
+		case "id", "userId", "deletedAt":
+			continue
+		case "version":
+			if version == 0 {
+				Schedule.Version, err = patchVersion(raw)
+			}
+		case "startTime":
+			Schedule.StartTime, err = patchTime(raw)
+		case "endTime":
+			Schedule.EndTime, err = patchTime(raw)
+		case "isAllDay":
+			Schedule.IsAllDay, err = patchBool(raw)
+		case "title":
+			Schedule.Title, err = patchString(raw, true)
+		case "description":
+			Schedule.Description, err = patchString(raw, false)
+		case "location":
+			Schedule.Location, err = patchString(raw, false)
+		case "category":
+			Schedule.Category, err = patchString(raw, false)
+		case "categoryId":
+			Schedule.CategoryId, err = patchUUID(raw)
+		case "roomId":
+			Schedule.RoomId, err = patchUUID(raw)
+		case "visibility":
+			Schedule.Visibility, err = patchString(raw, true)
+			if err == nil && normalizeVisibility(&Schedule) != nil {
+				err = ErrInvalidVisibility
+			}
+		default:
+			err = errUnknownField
+		}
+		if err != nil {
+			fieldErrors[field] = err.Error()
+		}
+	}
+	if version != 0 {
+		Schedule.Version = version
+	}
+
+	// A new location or category name replaces the room or category it was
+	// derived from, unless the patch also picks the new reference explicitly.
+	if _, ok := patch["roomId"]; !ok && Schedule.Location != existing.Location {
+		Schedule.RoomId = nil
+	}
+	if _, ok := patch["categoryId"]; !ok && Schedule.Category != existing.Category {
+		Schedule.CategoryId = nil
+	}
+	if _, ok := patch["category"]; !ok && Schedule.CategoryId == nil && existing.CategoryId != nil {
+		Schedule.Category = ""
+	}
+
+	if !Schedule.IsAllDay && !Schedule.EndTime.After(Schedule.StartTime) {
+		fieldErrors["endTime"] = "must be after startTime"
+	}
+	if len(fieldErrors) > 0 {
+		return entities.Schedule{}, fieldErrors
+	}
+
+	if err := s.UpdateSchedule(userId, Schedule); err != nil {
+		return entities.Schedule{}, err
+	}
+
+	return s.repo.FindSchedule(id)
 }
 
-func (s *scheduleService) AcceptSchedule(id uuid.UUID) error {
-	err := s.repo.AcceptSchedule(id)
+// RevertSchedule puts a schedule back to the state captured by one of its
+// revisions. It needs the same permission as an edit, and a trashed schedule
+// must be restored first.
+func (s *scheduleService) RevertSchedule(revisionId uuid.UUID, userId uuid.UUID) (entities.Schedule, error) {
+	revision, err := s.revisionService.GetRevisionByID(revisionId)
+	if err != nil {
+		return entities.Schedule{}, err
+	}
+
+	existing, err := s.AuthorizeSchedule(revision.ScheduleId, userId, SchedulePermissionEdit)
 	if err != nil {
+		return entities.Schedule{}, err
+	}
+
+	Schedule := existing
+	Schedule.StartTime = revision.Snapshot.StartTime
+	Schedule.EndTime = revision.Snapshot.EndTime
+	Schedule.IsAllDay = revision.Snapshot.IsAllDay
+	Schedule.Title = revision.Snapshot.Title
+	Schedule.Description = revision.Snapshot.Description
+	Schedule.Location = revision.Snapshot.Location
+	Schedule.Category = revision.Snapshot.Category
+	Schedule.CategoryId = revision.Snapshot.CategoryId
+	Schedule.RoomId = revision.Snapshot.RoomId
+	Schedule.Visibility = revision.Snapshot.Visibility
+
+	if err := s.update(Schedule, existing, entities.RevisionReverted, userId); err != nil {
+		return entities.Schedule{}, err
+	}
+
+	return s.repo.FindSchedule(Schedule.Id)
+}
+
+func (s *scheduleService) update(Schedule entities.Schedule, existing entities.Schedule, action string, actorId uuid.UUID) error {
+	if err := s.prepare(&Schedule); err != nil {
 		return err
 	}
 
+	err := s.repo.UpdateSchedule(Schedule)
+	if err != nil {
+		return err
+	}
+
+	s.revisionService.Record(action, actorId, &existing, &Schedule)
 	return nil
 }
 
-func (s *scheduleService) RejectSchedule(id uuid.UUID) error {
-	err := s.repo.RejectSchedule(id)
+// DeleteSchedule moves a schedule to the trash and tells its participants.
+// Only the owner may delete.
+func (s *scheduleService) DeleteSchedule(id uuid.UUID, userId uuid.UUID) error {
+	schedule, err := s.AuthorizeSchedule(id, userId, SchedulePermissionDelete)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.DeleteSchedule(id)
 	if err != nil {
 		return err
 	}
 
+	s.deleted(schedule, userId)
 	return nil
 }
 
+// deleted records the revision and tells participants once a delete has committed.
+func (s *scheduleService) deleted(schedule entities.Schedule, userId uuid.UUID) {
+	s.revisionService.Record(entities.RevisionDeleted, userId, &schedule, nil)
+
+	notifyParticipants(s.repo, s.notificationService, schedule, "ScheduleDeleted",
+		fmt.Sprintf("%s on %s was cancelled", schedule.Title, utils.FormatScheduleTime(schedule.StartTime)))
+}
+
+// AcceptSchedule accepts the invitation with participant row id on behalf of userId.
+func (s *scheduleService) AcceptSchedule(id uuid.UUID, userId uuid.UUID) error {
+	return s.respond(id, userId, "Accepted")
+}
+
+func (s *scheduleService) RejectSchedule(id uuid.UUID, userId uuid.UUID) error {
+	return s.respond(id, userId, "Rejected")
+}
+
 func (s *scheduleService) GetAllScheduleRequestsByUser(userID uuid.UUID) ([]entities.ScheduleParticipantResponse, error) {
 	participants, err := s.repo.GetAllScheduleRequestsByUser(userID)
 	if err != nil {
@@ -144,7 +347,13 @@ func (s *scheduleService) GetAllScheduleRequestsByUser(userID uuid.UUID) ([]enti
 	return responses, nil
 }
 
-func (s *scheduleService) GetAllScheduleRequestsBySchedule(scheduleID uuid.UUID) ([]entities.ScheduleParticipantResponse, error) {
+// GetAllScheduleRequestsBySchedule lists the invitations to a schedule the user
+// is allowed to read.
+func (s *scheduleService) GetAllScheduleRequestsBySchedule(scheduleID uuid.UUID, userId uuid.UUID) ([]entities.ScheduleParticipantResponse, error) {
+	if _, err := s.AuthorizeSchedule(scheduleID, userId, SchedulePermissionRead); err != nil {
+		return nil, err
+	}
+
 	participants, err := s.repo.GetAllScheduleRequestsBySchedule(scheduleID)
 	if err != nil {
 		return nil, err
@@ -174,7 +383,11 @@ func (s *scheduleService) GetAllScheduleRequestsBySchedule(scheduleID uuid.UUID)
 	return responses, nil
 }
 
-func (s *scheduleService) GetAllAcceptedSchedulesBySchedule(scheduleID uuid.UUID) ([]entities.ScheduleParticipantResponse, error) {
+func (s *scheduleService) GetAllAcceptedSchedulesBySchedule(scheduleID uuid.UUID, userId uuid.UUID) ([]entities.ScheduleParticipantResponse, error) {
+	if _, err := s.AuthorizeSchedule(scheduleID, userId, SchedulePermissionRead); err != nil {
+		return nil, err
+	}
+
 	participants, err := s.repo.GetAllAcceptedSchedulesBySchedule(scheduleID)
 	if err != nil {
 		return nil, err
@@ -204,6 +417,128 @@ func (s *scheduleService) GetAllAcceptedSchedulesBySchedule(scheduleID uuid.UUID
 	return responses, nil
 }
 
+func (s *scheduleService) CreateScheduleWithParticipants(Schedule entities.Schedule, participantIds []uuid.UUID) error {
+	if err := s.prepare(&Schedule); err != nil {
+		return err
+	}
+
+	participants := make([]entities.ScheduleParticipant, 0, len(participantIds))
+	for _, participantId := range participantIds {
+		if participantId == Schedule.UserId {
+			continue
+		}
+
+		participants = append(participants, entities.ScheduleParticipant{
+			Id:         uuid.New(),
+			ScheduleId: Schedule.Id,
+			UserId:     participantId,
+			Status:     "Pending",
+		})
+	}
+
+	err := s.repo.CreateScheduleWithParticipants(Schedule, participants)
+	if err != nil {
+		return err
+	}
+
+	s.revisionService.Record(entities.RevisionCreated, Schedule.UserId, nil, &Schedule)
+	return nil
+}
+
+// GetFriendSchedules returns ownerId's schedules as viewerId may see them.
+// Non-followers only see public schedules; followers also see busy and details
+// schedules, with busy ones redacted to a plain time slot. Private schedules
+// are only visible to their owner.
+func (s *scheduleService) GetFriendSchedules(viewerId uuid.UUID, ownerId uuid.UUID) ([]entities.Schedule, error) {
+	schedules, err := s.repo.GetAllSchedules(ownerId.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if viewerId == ownerId {
+		return schedules, nil
+	}
+
+	_, err = s.followRepo.GetFollowByUserAndFollower(viewerId, ownerId)
+	isFollower := err == nil
+
+	visible := make([]entities.Schedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		switch schedule.Visibility {
+		case entities.VisibilityPublic:
+			visible = append(visible, schedule)
+		case entities.VisibilityDetails:
+			if isFollower {
+				visible = append(visible, schedule)
+			}
+		case entities.VisibilityBusy:
+			if isFollower {
+				visible = append(visible, redactSchedule(schedule))
+			}
+		}
+	}
+
+	return visible, nil
+}
+
+// prepare normalizes a schedule and resolves its references before it is written.
+func (s *scheduleService) prepare(Schedule *entities.Schedule) error {
+	normalizeAllDay(Schedule)
+	if err := normalizeVisibility(Schedule); err != nil {
+		return err
+	}
+	if err := s.categoryService.ResolveScheduleCategory(Schedule); err != nil {
+		return err
+	}
+
+	return s.roomService.ResolveScheduleRoom(Schedule)
+}
+
+// normalizeAllDay snaps an all-day schedule to UTC midnights on the dates it was
+// given. EndTime becomes exclusive: an end that is already a midnight is kept,
+// any other end time means "through that day". A schedule always spans at least one day.
+func normalizeAllDay(Schedule *entities.Schedule) {
+	if !Schedule.IsAllDay {
+		return
+	}
+
+	start := utils.DateOf(Schedule.StartTime)
+	end := utils.DateOf(Schedule.EndTime)
+	if !utils.IsMidnight(Schedule.EndTime) {
+		end = end.AddDate(0, 0, 1)
+	}
+	if !end.After(start) {
+		end = start.AddDate(0, 0, 1)
+	}
+
+	Schedule.StartTime = start
+	Schedule.EndTime = end
+}
+
+func normalizeVisibility(Schedule *entities.Schedule) error {
+	switch Schedule.Visibility {
+	case "":
+		Schedule.Visibility = entities.VisibilityBusy
+	case entities.VisibilityPrivate, entities.VisibilityBusy, entities.VisibilityDetails, entities.VisibilityPublic:
+	default:
+		return ErrInvalidVisibility
+	}
+
+	return nil
+}
+
+// redactSchedule keeps only what a busy-only viewer may know: when the owner is busy.
+func redactSchedule(Schedule entities.Schedule) entities.Schedule {
+	return entities.Schedule{
+		Id:         Schedule.Id,
+		UserId:     Schedule.UserId,
+		StartTime:  Schedule.StartTime,
+		EndTime:    Schedule.EndTime,
+		Title:      "Busy",
+		Visibility: Schedule.Visibility,
+	}
+}
+
 func ValidateSchedule(Schedule entities.Schedule) bool {
 	// Fill this part with attributes and its validations
 
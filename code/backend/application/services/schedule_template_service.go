@@ -0,0 +1,224 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidTemplate  = errors.New("invalid template")
+	ErrTemplateNotFound = errors.New("template not found")
+)
+
+type ScheduleTemplateService interface {
+	CreateNewTemplate(Template entities.ScheduleTemplate) (entities.ScheduleTemplate, error)
+	GetTemplateByID(id uuid.UUID) (entities.ScheduleTemplate, error)
+	GetTemplatesByUser(userId uuid.UUID) ([]entities.ScheduleTemplate, error)
+	UpdateTemplate(Template entities.ScheduleTemplate) error
+	DeleteTemplate(id uuid.UUID, userId uuid.UUID) error
+	InstantiateTemplate(id uuid.UUID, userId uuid.UUID, startTime time.Time) (entities.Schedule, error)
+}
+
+type scheduleTemplateService struct {
+	repo            repositories.ScheduleTemplateRepository
+	userRepo        repositories.UserRepository
+	categoryService CategoryService
+	roomService     RoomService
+	reminderService ReminderService
+	scheduleService ScheduleService
+}
+
+func NewScheduleTemplateService() ScheduleTemplateService {
+	return &scheduleTemplateService{
+		repo:            repositories.NewScheduleTemplateRepository(),
+		userRepo:        repositories.NewUserRepository(),
+		categoryService: NewCategoryService(),
+		roomService:     NewRoomService(),
+		reminderService: NewReminderService(),
+		scheduleService: NewScheduleService(),
+	}
+}
+
+func (s *scheduleTemplateService) CreateNewTemplate(Template entities.ScheduleTemplate) (entities.ScheduleTemplate, error) {
+	Template.Id = uuid.New()
+	if err := s.validate(&Template); err != nil {
+		return entities.ScheduleTemplate{}, err
+	}
+
+	if err := s.repo.CreateNewScheduleTemplate(Template); err != nil {
+		return entities.ScheduleTemplate{}, err
+	}
+
+	return Template, nil
+}
+
+func (s *scheduleTemplateService) GetTemplateByID(id uuid.UUID) (entities.ScheduleTemplate, error) {
+	template, err := s.repo.FindScheduleTemplate(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.ScheduleTemplate{}, ErrTemplateNotFound
+	}
+	if err != nil {
+		return entities.ScheduleTemplate{}, err
+	}
+
+	return template, nil
+}
+
+func (s *scheduleTemplateService) GetTemplatesByUser(userId uuid.UUID) ([]entities.ScheduleTemplate, error) {
+	templates, err := s.repo.GetScheduleTemplatesByUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (s *scheduleTemplateService) UpdateTemplate(Template entities.ScheduleTemplate) error {
+	existing, err := s.GetTemplateByID(Template.Id)
+	if err != nil {
+		return err
+	}
+	if existing.UserId != Template.UserId {
+		return ErrTemplateNotFound
+	}
+
+	if err := s.validate(&Template); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateScheduleTemplate(Template)
+}
+
+func (s *scheduleTemplateService) DeleteTemplate(id uuid.UUID, userId uuid.UUID) error {
+	template, err := s.GetTemplateByID(id)
+	if err != nil {
+		return err
+	}
+	if template.UserId != userId {
+		return ErrTemplateNotFound
+	}
+
+	return s.repo.DeleteScheduleTemplate(id)
+}
+
+// InstantiateTemplate creates a schedule from a template starting at startTime,
+// invites the template's participants and sets its reminders.
+func (s *scheduleTemplateService) InstantiateTemplate(id uuid.UUID, userId uuid.UUID, startTime time.Time) (entities.Schedule, error) {
+	template, err := s.GetTemplateByID(id)
+	if err != nil {
+		return entities.Schedule{}, err
+	}
+	if template.UserId != userId {
+		return entities.Schedule{}, ErrTemplateNotFound
+	}
+	if startTime.IsZero() {
+		return entities.Schedule{}, fmt.Errorf("%w: start time is required", ErrInvalidTemplate)
+	}
+
+	schedule := entities.Schedule{
+		Id:          uuid.New(),
+		UserId:      userId,
+		StartTime:   startTime,
+		EndTime:     startTime.Add(time.Duration(template.DurationMinutes) * time.Minute),
+		Title:       template.Title,
+		Description: template.Description,
+		Location:    template.Location,
+		RoomId:      template.RoomId,
+		Category:    template.Category,
+		CategoryId:  template.CategoryId,
+		Visibility:  template.Visibility,
+	}
+	if err := s.scheduleService.CreateScheduleWithParticipants(schedule, template.ParticipantIds); err != nil {
+		return entities.Schedule{}, err
+	}
+
+	// The schedule exists at this point, so a reminder failure must not be
+	// reported as a failed instantiation that the client would retry.
+	if len(template.Reminders) > 0 {
+		if err := s.reminderService.SetScheduleReminders(userId, schedule.Id, templateReminders(template.Reminders)); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return s.scheduleService.GetScheduleByID(schedule.Id)
+}
+
+func (s *scheduleTemplateService) validate(Template *entities.ScheduleTemplate) error {
+	Template.Name = strings.TrimSpace(Template.Name)
+	Template.Title = strings.TrimSpace(Template.Title)
+
+	if Template.UserId == uuid.Nil || Template.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTemplate)
+	}
+	if Template.Title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidTemplate)
+	}
+	if Template.DurationMinutes <= 0 {
+		return fmt.Errorf("%w: duration must be positive", ErrInvalidTemplate)
+	}
+
+	probe := entities.Schedule{Visibility: Template.Visibility}
+	if err := normalizeVisibility(&probe); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	Template.Visibility = probe.Visibility
+
+	if Template.CategoryId != nil {
+		category, err := s.categoryService.GetCategoryByID(*Template.CategoryId)
+		if errors.Is(err, ErrCategoryNotFound) || (err == nil && category.UserId != Template.UserId) {
+			return fmt.Errorf("%w: category does not exist", ErrInvalidTemplate)
+		}
+		if err != nil {
+			return err
+		}
+		Template.Category = category.Name
+	}
+
+	if Template.RoomId != nil {
+		room, err := s.roomService.GetRoomByID(*Template.RoomId)
+		if errors.Is(err, ErrRoomNotFound) {
+			return fmt.Errorf("%w: room does not exist", ErrInvalidTemplate)
+		}
+		if err != nil {
+			return err
+		}
+		Template.Location = room.Name
+	}
+
+	participantIds := make([]uuid.UUID, 0, len(Template.ParticipantIds))
+	for _, participantId := range uniqueUserIds(Template.ParticipantIds) {
+		if participantId == Template.UserId {
+			continue
+		}
+		if _, err := s.userRepo.FindUser(participantId); errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: participant %s does not exist", ErrInvalidTemplate, participantId)
+		} else if err != nil {
+			return err
+		}
+		participantIds = append(participantIds, participantId)
+	}
+	Template.ParticipantIds = participantIds
+
+	if err := s.reminderService.ValidateReminders(Template.UserId, templateReminders(Template.Reminders)); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	return nil
+}
+
+func templateReminders(reminders []entities.TemplateReminder) []entities.Reminder {
+	converted := make([]entities.Reminder, 0, len(reminders))
+	for _, reminder := range reminders {
+		converted = append(converted, entities.Reminder{OffsetMinutes: reminder.OffsetMinutes, Channel: reminder.Channel})
+	}
+
+	return converted
+}
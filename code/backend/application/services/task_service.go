@@ -0,0 +1,166 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidTask  = errors.New("invalid task")
+	ErrTaskNotFound = errors.New("task not found")
+)
+
+type TaskService interface {
+	CreateNewTask(Task entities.Task) (entities.Task, error)
+	GetTaskByID(id uuid.UUID) (entities.Task, error)
+	GetTasks(query entities.TaskQuery) ([]entities.Task, error)
+	UpdateTask(Task entities.Task) error
+	SetTaskCompleted(id uuid.UUID, userId uuid.UUID, completed bool) error
+	DeleteTask(id uuid.UUID, userId uuid.UUID) error
+}
+
+type taskService struct {
+	repo            repositories.TaskRepository
+	scheduleService ScheduleService
+}
+
+func NewTaskService() TaskService {
+	return &taskService{
+		repo:            repositories.NewTaskRepository(),
+		scheduleService: NewScheduleService(),
+	}
+}
+
+func (s *taskService) CreateNewTask(Task entities.Task) (entities.Task, error) {
+	Task.Id = uuid.New()
+	Task.IsCompleted = false
+	Task.CompletedAt = nil
+	if err := s.validate(&Task); err != nil {
+		return entities.Task{}, err
+	}
+
+	if err := s.repo.CreateNewTask(Task); err != nil {
+		return entities.Task{}, err
+	}
+
+	return Task, nil
+}
+
+func (s *taskService) GetTaskByID(id uuid.UUID) (entities.Task, error) {
+	task, err := s.repo.FindTask(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.Task{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return entities.Task{}, err
+	}
+
+	return task, nil
+}
+
+func (s *taskService) GetTasks(query entities.TaskQuery) ([]entities.Task, error) {
+	tasks, err := s.repo.GetTasks(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// UpdateTask replaces a task's details. Completion is changed through SetTaskCompleted.
+func (s *taskService) UpdateTask(Task entities.Task) error {
+	existing, err := s.GetTaskByID(Task.Id)
+	if err != nil {
+		return err
+	}
+	if existing.UserId != Task.UserId {
+		return ErrTaskNotFound
+	}
+
+	Task.IsCompleted = existing.IsCompleted
+	Task.CompletedAt = existing.CompletedAt
+	if err := s.validate(&Task); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateTask(Task)
+}
+
+func (s *taskService) SetTaskCompleted(id uuid.UUID, userId uuid.UUID, completed bool) error {
+	task, err := s.GetTaskByID(id)
+	if err != nil {
+		return err
+	}
+	if task.UserId != userId {
+		return ErrTaskNotFound
+	}
+	if task.IsCompleted == completed {
+		return nil
+	}
+
+	task.IsCompleted = completed
+	task.CompletedAt = nil
+	if completed {
+		now := time.Now()
+		task.CompletedAt = &now
+	}
+
+	return s.repo.UpdateTask(task)
+}
+
+func (s *taskService) DeleteTask(id uuid.UUID, userId uuid.UUID) error {
+	task, err := s.GetTaskByID(id)
+	if err != nil {
+		return err
+	}
+	if task.UserId != userId {
+		return ErrTaskNotFound
+	}
+
+	return s.repo.DeleteTask(id)
+}
+
+func (s *taskService) validate(Task *entities.Task) error {
+	Task.Title = strings.TrimSpace(Task.Title)
+	Task.Course = strings.TrimSpace(Task.Course)
+	if Task.Priority == "" {
+		Task.Priority = entities.TaskPriorityMedium
+	}
+
+	if Task.UserId == uuid.Nil || Task.Title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidTask)
+	}
+	if Task.DueDate.IsZero() {
+		return fmt.Errorf("%w: due date is required", ErrInvalidTask)
+	}
+	if Task.EstimatedMinutes < 0 {
+		return fmt.Errorf("%w: estimated effort cannot be negative", ErrInvalidTask)
+	}
+
+	switch Task.Priority {
+	case entities.TaskPriorityLow, entities.TaskPriorityMedium, entities.TaskPriorityHigh:
+	default:
+		return fmt.Errorf("%w: priority must be Low, Medium or High", ErrInvalidTask)
+	}
+
+	// The link is exported with the task, so the user must be able to see the
+	// schedule. A schedule they cannot read is reported as missing.
+	if Task.ScheduleId != nil {
+		_, err := s.scheduleService.AuthorizeSchedule(*Task.ScheduleId, Task.UserId, SchedulePermissionRead)
+		if errors.Is(err, ErrScheduleNotFound) || errors.Is(err, ErrScheduleForbidden) {
+			return fmt.Errorf("%w: linked schedule does not exist", ErrInvalidTask)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/storage"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TrashService lists, restores and purges schedules deleted through ScheduleService.
+type TrashService interface {
+	GetTrash(userId uuid.UUID) ([]entities.Schedule, error)
+	RestoreSchedule(id uuid.UUID, userId uuid.UUID) error
+	PurgeDeletedBefore(before time.Time) (int, error)
+}
+
+type trashService struct {
+	repo                repositories.ScheduleRepository
+	attachmentRepo      repositories.AttachmentRepository
+	blobs               storage.BlobStore
+	notificationService NotificationService
+	revisionService     ScheduleRevisionService
+}
+
+func NewTrashService() TrashService {
+	return &trashService{
+		repo:                repositories.NewScheduleRepository(),
+		attachmentRepo:      repositories.NewAttachmentRepository(),
+		blobs:               storage.NewLocalBlobStore(),
+		notificationService: NewNotificationService(),
+		revisionService:     NewScheduleRevisionService(),
+	}
+}
+
+func (s *trashService) GetTrash(userId uuid.UUID) ([]entities.Schedule, error) {
+	schedules, err := s.repo.GetDeletedSchedulesByUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+func (s *trashService) RestoreSchedule(id uuid.UUID, userId uuid.UUID) error {
+	schedule, err := s.repo.FindDeletedSchedule(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && schedule.UserId != userId) {
+		return ErrScheduleNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.RestoreSchedule(schedule); err != nil {
+		return err
+	}
+	s.revisionService.Record(entities.RevisionRestored, userId, nil, &schedule)
+
+	notifyParticipants(s.repo, s.notificationService, schedule, "ScheduleRestored",
+		fmt.Sprintf("%s on %s is back on", schedule.Title, utils.FormatScheduleTime(schedule.StartTime)))
+	return nil
+}
+
+// PurgeDeletedBefore permanently removes schedules trashed before the cutoff,
+// including their attachment files. A schedule that fails is logged and left
+// for the next run so it cannot hold up the others. It returns how many
+// schedules were purged together with every failure.
+func (s *trashService) PurgeDeletedBefore(before time.Time) (int, error) {
+	ids, err := s.repo.GetDeletedScheduleIdsBefore(before)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	failures := make([]error, 0)
+	for _, id := range ids {
+		if err := s.purge(id); err != nil {
+			err = fmt.Errorf("purge schedule %s: %w", id, err)
+			log.Println(err)
+			failures = append(failures, err)
+			continue
+		}
+		purged++
+	}
+
+	return purged, errors.Join(failures...)
+}
+
+// purge deletes the attachment files first, so a failure leaves the schedule
+// in the trash to be retried rather than orphaning its files.
+func (s *trashService) purge(id uuid.UUID) error {
+	attachments, err := s.attachmentRepo.GetAttachmentsBySchedule(id)
+	if err != nil {
+		return err
+	}
+	for _, attachment := range attachments {
+		if err := s.blobs.Delete(attachment.StorageKey); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.PurgeSchedule(id)
+}
+
+// notifyParticipants tells everyone who has not declined a schedule about a change to it.
+// Failures are logged rather than returned so they never undo the change itself.
+func notifyParticipants(repo repositories.ScheduleRepository, notificationService NotificationService, schedule entities.Schedule, kind string, message string) {
+	participants, err := repo.GetAllScheduleRequestsBySchedule(schedule.Id)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	scheduleId := schedule.Id
+	for _, participant := range participants {
+		if participant.Status == "Rejected" || participant.UserId == schedule.UserId {
+			continue
+		}
+
+		if err := notificationService.Notify(entities.Notification{
+			UserId:     participant.UserId,
+			ScheduleId: &scheduleId,
+			Type:       kind,
+			Title:      schedule.Title,
+			Message:    message,
+		}); err != nil {
+			log.Println(err)
+		}
+	}
+}
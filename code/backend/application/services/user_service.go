@@ -3,6 +3,7 @@ package services
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
 	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/repositories"
@@ -22,6 +23,7 @@ type UserService interface {
 	Login(email string, password string) (entities.User, error)
 	GetAllUsers() ([]entities.User, error)
 	UpdateUser(User entities.User) error
+	PatchUser(id uuid.UUID, patch MergePatch, version int) (entities.User, error)
 	DeleteUser(email string) error
 	GetFollowersByUser(userId uuid.UUID) ([]entities.User, error)
 	GetFollowingByUser(userId uuid.UUID) ([]entities.User, error)
@@ -32,6 +34,7 @@ type userService struct {
 	repo              repositories.UserRepository
 	followRepo        repositories.FollowRepository
 	followRequestRepo repositories.FollowRequestRepository
+	categoryService   CategoryService
 }
 
 func NewUserService() UserService {
@@ -39,6 +42,7 @@ func NewUserService() UserService {
 		repo:              repositories.NewUserRepository(),
 		followRepo:        repositories.NewFollowRepository(),
 		followRequestRepo: repositories.NewFollowRequestRepository(),
+		categoryService:   NewCategoryService(),
 	}
 }
 
@@ -64,6 +68,7 @@ func CheckPassword(hashedPassword, inputPassword string) bool {
 
 func (s *userService) CreateNewUser(user entities.User) error {
 	user.Id = uuid.New()
+	user.Role = entities.RoleUser
 
 	existingUser, err := s.repo.FindUserByEmail(user.Email)
 	if err == nil && existingUser.Email != "" {
@@ -78,7 +83,7 @@ func (s *userService) CreateNewUser(user entities.User) error {
 		return err
 	}
 
-	return nil
+	return s.categoryService.SeedDefaultCategories(user.Id)
 }
 
 func (s *userService) GetUserByID(id string) (entities.User, error) {
@@ -99,8 +104,31 @@ func (s *userService) GetAllUsers() ([]entities.User, error) {
 	return Users, nil
 }
 
+// UpdateUser follows the same version rules as ScheduleService.UpdateSchedule.
+// The role is never taken from the client, and the password only changes when
+// a new one is given.
 func (s *userService) UpdateUser(User entities.User) error {
-	err := s.repo.UpdateUser(User)
+	existing, err := s.repo.FindUser(User.Id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	User.Role = existing.Role
+	if User.Password == "" {
+		User.Password = existing.Password
+	}
+
+	if User.Version == 0 {
+		User.Version = existing.Version
+	}
+	if User.Version != existing.Version {
+		return ErrVersionConflict
+	}
+
+	err = s.repo.UpdateUser(User)
 	if err != nil {
 		return err
 	}
@@ -108,6 +136,86 @@ func (s *userService) UpdateUser(User entities.User) error {
 	return nil
 }
 
+// PatchUser applies a merge patch to a user the way PatchSchedule does for
+// schedules. id and role are ignored; a new email must not belong to anyone else.
+func (s *userService) PatchUser(id uuid.UUID, patch MergePatch, version int) (entities.User, error) {
+	User, err := s.repo.FindUser(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	fieldErrors := FieldErrors{}
+	for field, raw := range patch {
+		var err error
+		switch field {
+		case "id", "role":
+			continue
+		case "version":
+			if version == 0 {
+				User.Version, err = patchVersion(raw)
+			}
+		case "name":
+			User.Name, err = patchString(raw, true)
+		case "studentId":
+			User.StudentId, err = patchString(raw, true)
+		case "email":
+			User.Email, err = patchString(raw, true)
+			if err == nil && !strings.Contains(User.Email, "@") {
+				err = errors.New("must be an email address")
+			}
+			if err == nil {
+				taken, lookupErr := s.emailTaken(User.Email, id)
+				if lookupErr != nil {
+					return entities.User{}, lookupErr
+				}
+				if taken {
+					err = errors.New("is already in use")
+				}
+			}
+		case "password":
+			User.Password, err = patchString(raw, true)
+		case "major":
+			User.Major, err = patchString(raw, false)
+		case "profilePicture":
+			User.ProfilePicture, err = patchString(raw, false)
+		case "isActive":
+			User.IsActive, err = patchBool(raw)
+		default:
+			err = errUnknownField
+		}
+		if err != nil {
+			fieldErrors[field] = err.Error()
+		}
+	}
+	if version != 0 {
+		User.Version = version
+	}
+	if len(fieldErrors) > 0 {
+		return entities.User{}, fieldErrors
+	}
+
+	if err := s.UpdateUser(User); err != nil {
+		return entities.User{}, err
+	}
+
+	return s.repo.FindUser(id)
+}
+
+func (s *userService) emailTaken(email string, userId uuid.UUID) (bool, error) {
+	existing, err := s.repo.FindUserByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return existing.Id != userId, nil
+}
+
 func (s *userService) DeleteUser(email string) error {
 	err := s.repo.DeleteUser(email)
 	if err != nil {
@@ -1,6 +1,8 @@
 package main
 
 import (
+	_ "time/tzdata"
+
 	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database/migrations"
 	"github.com/WillyWinata/WebDevelopment-Personal/backend/presentation/routes"
 	"github.com/gin-contrib/cors"
@@ -16,6 +18,19 @@ func initialize() {
 	scheduleMigration.MigrateSchedule()
 	scheduleMigration.SeedSchedule()
 
+	scheduleSearchMigration := migrations.NewScheduleSearchMigration()
+	scheduleSearchMigration.MigrateScheduleSearch()
+
+	categoryMigration := migrations.NewCategoryMigration()
+	categoryMigration.MigrateCategory()
+	categoryMigration.SeedCategory()
+	categoryMigration.BackfillScheduleCategories()
+
+	roomMigration := migrations.NewRoomMigration()
+	roomMigration.MigrateRoom()
+	roomMigration.SeedRoom()
+	roomMigration.BackfillScheduleRooms()
+
 	followMigration := migrations.NewFollowMigration()
 	followMigration.MigrateFollow()
 	followMigration.SeedFollow()
@@ -23,12 +38,53 @@ func initialize() {
 	followRequestMigration := migrations.NewFollowRequestMigration()
 	followRequestMigration.MigrateFollowRequest()
 
+	personalTokenMigration := migrations.NewPersonalTokenMigration()
+	personalTokenMigration.MigratePersonalToken()
+
+	calDAVResourceMigration := migrations.NewCalDAVResourceMigration()
+	calDAVResourceMigration.MigrateCalDAVResource()
+
+	notificationMigration := migrations.NewNotificationMigration()
+	notificationMigration.MigrateNotification()
+
+	reminderMigration := migrations.NewReminderMigration()
+	reminderMigration.MigrateReminder()
+
+	attachmentMigration := migrations.NewAttachmentMigration()
+	attachmentMigration.MigrateAttachment()
+
+	commentMigration := migrations.NewCommentMigration()
+	commentMigration.MigrateComment()
+
+	taskMigration := migrations.NewTaskMigration()
+	taskMigration.MigrateTask()
+
+	scheduleRevisionMigration := migrations.NewScheduleRevisionMigration()
+	scheduleRevisionMigration.MigrateScheduleRevision()
+
+	scheduleTemplateMigration := migrations.NewScheduleTemplateMigration()
+	scheduleTemplateMigration.MigrateScheduleTemplate()
+
+	scheduleProposalMigration := migrations.NewScheduleProposalMigration()
+	scheduleProposalMigration.MigrateScheduleProposal()
+
+	meetingPollMigration := migrations.NewMeetingPollMigration()
+	meetingPollMigration.MigrateMeetingPoll()
+
+	academicCalendarMigration := migrations.NewAcademicCalendarMigration()
+	academicCalendarMigration.MigrateAcademicCalendar()
+	academicCalendarMigration.SeedAcademicCalendar()
+
+	startReminderWorker()
+	startTrashWorker()
+
 	r := gin.Default()
 
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:5173"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "If-Match"},
+		ExposeHeaders:    []string{"ETag"},
 		AllowCredentials: true,
 	}))
 
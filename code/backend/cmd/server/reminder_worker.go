@@ -0,0 +1,34 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+)
+
+const reminderWorkerInterval = 30 * time.Second
+
+// startReminderWorker periodically dispatches due reminders. Delivery state lives
+// in the database, so the worker can be stopped and restarted at any time.
+func startReminderWorker() {
+	reminderService := services.NewReminderService()
+	loc := utils.ScheduleLocation()
+
+	go func() {
+		ticker := time.NewTicker(reminderWorkerInterval)
+		defer ticker.Stop()
+
+		for {
+			sent, err := reminderService.DispatchDueReminders(utils.WallClockNow(loc))
+			if err != nil {
+				log.Printf("Reminder worker: %v\n", err)
+			} else if sent > 0 {
+				log.Printf("Reminder worker: sent %d reminder(s)\n", sent)
+			}
+
+			<-ticker.C
+		}
+	}()
+}
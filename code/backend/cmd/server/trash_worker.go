@@ -0,0 +1,49 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+)
+
+const trashWorkerInterval = time.Hour
+
+// startTrashWorker purges schedules that have been in the trash for longer than
+// TRASH_RETENTION_DAYS (default 30).
+func startTrashWorker() {
+	trashService := services.NewTrashService()
+	retention := trashRetention()
+
+	go func() {
+		ticker := time.NewTicker(trashWorkerInterval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := trashService.PurgeDeletedBefore(time.Now().Add(-retention))
+			if err != nil {
+				log.Printf("Trash worker: %v\n", err)
+			} else if purged > 0 {
+				log.Printf("Trash worker: purged %d schedule(s)\n", purged)
+			}
+
+			<-ticker.C
+		}
+	}()
+}
+
+func trashRetention() time.Duration {
+	days := 30
+	if value := os.Getenv("TRASH_RETENTION_DAYS"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			log.Printf("Trash worker: invalid TRASH_RETENTION_DAYS %q, using %d\n", value, days)
+		} else {
+			days = parsed
+		}
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
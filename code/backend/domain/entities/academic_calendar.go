@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AcademicTerm is a semester. Dates are UTC midnights and EndDate is the last
+// day of the term.
+type AcademicTerm struct {
+	Id        uuid.UUID `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"not null" json:"name"`
+	StartDate time.Time `gorm:"type:date;not null" json:"startDate"`
+	EndDate   time.Time `gorm:"type:date;not null" json:"endDate"`
+}
+
+func (t *AcademicTerm) AfterFind(tx *gorm.DB) error {
+	t.StartDate = calendarDate(t.StartDate)
+	t.EndDate = calendarDate(t.EndDate)
+
+	return nil
+}
+
+// Contains reports whether the calendar date of t falls within the term.
+func (t AcademicTerm) Contains(date time.Time) bool {
+	return dateWithin(date, t.StartDate, t.EndDate)
+}
+
+// AcademicExclusion is a break inside a term or a holiday, during which
+// recurring schedules do not take place. Breaks belong to a term; holidays
+// usually have no TermId. EndDate is the last excluded day.
+type AcademicExclusion struct {
+	Id        uuid.UUID  `gorm:"primaryKey" json:"id"`
+	TermId    *uuid.UUID `gorm:"index" json:"termId"`
+	Name      string     `gorm:"not null" json:"name"`
+	Kind      string     `gorm:"not null" json:"kind"`
+	StartDate time.Time  `gorm:"type:date;not null;index" json:"startDate"`
+	EndDate   time.Time  `gorm:"type:date;not null" json:"endDate"`
+}
+
+const (
+	AcademicExclusionBreak   = "Break"
+	AcademicExclusionHoliday = "Holiday"
+)
+
+func (e *AcademicExclusion) AfterFind(tx *gorm.DB) error {
+	e.StartDate = calendarDate(e.StartDate)
+	e.EndDate = calendarDate(e.EndDate)
+
+	return nil
+}
+
+// Covers reports whether the calendar date of t is excluded.
+func (e AcademicExclusion) Covers(date time.Time) bool {
+	return dateWithin(date, e.StartDate, e.EndDate)
+}
+
+// calendarDate keeps the date of t as read in its own location and makes it a
+// UTC midnight. The driver returns date columns as midnights in its own zone,
+// so converting them to UTC could move them to the previous day.
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// dateWithin compares calendar dates only. Schedule times are wall-clock times,
+// so the date is read as written rather than converted between zones.
+func dateWithin(date time.Time, first time.Time, last time.Time) bool {
+	day := calendarDate(date)
+
+	return !day.Before(calendarDate(first)) && !day.After(calendarDate(last))
+}
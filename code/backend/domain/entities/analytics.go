@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalyticsQuery covers [Start, End). Like schedule times, the bounds are
+// wall-clock values held in UTC, so weekdays, hours and weeks are read in UTC.
+type AnalyticsQuery struct {
+	UserId uuid.UUID
+	Start  time.Time
+	End    time.Time
+}
+
+// TimeAnalytics is how a user's scheduled hours split up over a range. Hours
+// where events overlap are shared between them, so every breakdown sums to
+// TotalHours.
+type TimeAnalytics struct {
+	Start      time.Time       `json:"start"`
+	End        time.Time       `json:"end"`
+	TotalHours float64         `json:"totalHours"`
+	ByCategory []CategoryHours `json:"byCategory"`
+	ByWeekday  []WeekdayHours  `json:"byWeekday"`
+	ByHour     []float64       `json:"byHour"` // index 0 is 00:00-01:00
+	Weeks      []WeekHours     `json:"weeks"`
+}
+
+type CategoryHours struct {
+	Category string  `json:"category"`
+	Color    string  `json:"color"`
+	Hours    float64 `json:"hours"`
+}
+
+type WeekdayHours struct {
+	Weekday string  `json:"weekday"`
+	Hours   float64 `json:"hours"`
+}
+
+// WeekHours is one Monday-to-Sunday week of the range.
+type WeekHours struct {
+	WeekStart  time.Time          `json:"weekStart"`
+	TotalHours float64            `json:"totalHours"`
+	ByCategory map[string]float64 `json:"byCategory"`
+}
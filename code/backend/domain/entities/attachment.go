@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Attachment struct {
+	Id          uuid.UUID `gorm:"primaryKey" json:"id"`
+	ScheduleId  uuid.UUID `gorm:"not null;index" json:"scheduleId"`
+	UserId      uuid.UUID `gorm:"not null" json:"userId"` // uploader
+	FileName    string    `gorm:"not null" json:"fileName"`
+	ContentType string    `gorm:"not null" json:"contentType"`
+	Size        int64     `gorm:"not null" json:"size"`
+	StorageKey  string    `gorm:"not null" json:"-"`
+	CreatedAt   time.Time `gorm:"not null" json:"createdAt"`
+}
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bulk operations applied to every selected schedule.
+const (
+	BulkDelete         = "delete"
+	BulkShift          = "shift"
+	BulkChangeLocation = "location"
+	BulkChangeCategory = "category"
+)
+
+// BulkScheduleRequest selects schedules either by ScheduleIds or, when no IDs
+// are given, by Filter over the user's own schedules.
+type BulkScheduleRequest struct {
+	UserId      uuid.UUID
+	ScheduleIds []uuid.UUID
+	Filter      BulkScheduleFilter
+	Operation   string
+	Shift       time.Duration
+	Location    string
+	RoomId      *uuid.UUID
+	CategoryId  *uuid.UUID
+	DryRun      bool
+}
+
+// BulkScheduleFilter matches schedules overlapping [Start, End), optionally
+// narrowed to one category.
+type BulkScheduleFilter struct {
+	CategoryId *uuid.UUID
+	Start      time.Time
+	End        time.Time
+}
+
+type BulkScheduleItemResult struct {
+	ScheduleId uuid.UUID `json:"scheduleId"`
+	Status     string    `json:"status"` // "ok" or "failed"
+	Error      string    `json:"error,omitempty"`
+	Before     *Schedule `json:"before,omitempty"`
+	After      *Schedule `json:"after,omitempty"`
+}
+
+// BulkScheduleResult reports every selected schedule. Nothing is applied unless
+// all items succeed, and never on a dry run.
+type BulkScheduleResult struct {
+	DryRun  bool                     `json:"dryRun"`
+	Applied bool                     `json:"applied"`
+	Items   []BulkScheduleItemResult `json:"items"`
+}
@@ -0,0 +1,20 @@
+package entities
+
+import "github.com/google/uuid"
+
+// CalDAVResource remembers the href and UID a calendar client chose for a schedule
+// it created, so the client sees the same resource name on its next sync.
+type CalDAVResource struct {
+	Id         uuid.UUID `gorm:"primaryKey" json:"id"`
+	UserId     uuid.UUID `gorm:"not null" json:"userId"`
+	ScheduleId uuid.UUID `gorm:"not null;uniqueIndex" json:"scheduleId"`
+	Href       string    `gorm:"not null" json:"href"`
+	Uid        string    `gorm:"not null" json:"uid"`
+}
+
+type CalDAVObject struct {
+	Href     string   `json:"href"`
+	ETag     string   `json:"etag"`
+	Data     string   `json:"data"`
+	Schedule Schedule `json:"schedule"`
+}
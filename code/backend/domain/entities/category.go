@@ -0,0 +1,20 @@
+package entities
+
+import "github.com/google/uuid"
+
+type Category struct {
+	Id     uuid.UUID `gorm:"primaryKey" json:"id"`
+	UserId uuid.UUID `gorm:"not null;index" json:"userId"`
+	Name   string    `gorm:"not null" json:"name"`
+	Color  string    `gorm:"not null" json:"color"`
+	Icon   string    `gorm:"not null" json:"icon"`
+}
+
+// DefaultCategories are created for every new user. Colors match the frontend legend.
+var DefaultCategories = []Category{
+	{Name: "Study", Color: "#8b5cf6", Icon: "book-open"},
+	{Name: "Work", Color: "#ec4899", Icon: "briefcase"},
+	{Name: "Personal", Color: "#10b981", Icon: "user"},
+	{Name: "Social", Color: "#3b82f6", Icon: "users"},
+	{Name: "Organization", Color: "#f59e0b", Icon: "flag"},
+}
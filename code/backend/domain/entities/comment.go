@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Comment struct {
+	Id         uuid.UUID   `gorm:"primaryKey" json:"id"`
+	ScheduleId uuid.UUID   `gorm:"not null;index" json:"scheduleId"`
+	UserId     uuid.UUID   `gorm:"not null" json:"userId"`
+	Body       string      `gorm:"type:text;not null" json:"body"`
+	Mentions   []uuid.UUID `gorm:"type:text;serializer:json" json:"mentions"`
+	CreatedAt  time.Time   `gorm:"not null" json:"createdAt"`
+	EditedAt   *time.Time  `json:"editedAt"`
+}
+
+// CommentThreadLock exists while the organizer has closed a schedule's thread.
+type CommentThreadLock struct {
+	ScheduleId uuid.UUID `gorm:"primaryKey" json:"scheduleId"`
+	LockedBy   uuid.UUID `gorm:"not null" json:"lockedBy"`
+	LockedAt   time.Time `gorm:"not null" json:"lockedAt"`
+}
+
+type CommentPage struct {
+	Comments []Comment `json:"comments"`
+	Total    int64     `json:"total"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"pageSize"`
+	Locked   bool      `json:"locked"`
+}
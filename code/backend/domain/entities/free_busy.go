@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TimeSlot struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// FreeTimeQuery describes a common free time search. Like schedule times, the
+// dates are wall-clock values held in UTC. DayStart and DayEnd are offsets from
+// each date's midnight, e.g. 7h and 21h for 07:00-21:00.
+type FreeTimeQuery struct {
+	UserIds     []uuid.UUID
+	StartDate   time.Time
+	EndDate     time.Time
+	DayStart    time.Duration
+	DayEnd      time.Duration
+	MinDuration time.Duration
+}
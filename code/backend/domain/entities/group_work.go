@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GroupWorkQuery asks for meeting slots between EarliestStart and Deadline. Both
+// are wall-clock values held in UTC like schedule times, and the clock bounds are
+// offsets from each day's midnight.
+type GroupWorkQuery struct {
+	OrganizerId    uuid.UUID
+	ParticipantIds []uuid.UUID
+	Duration       time.Duration
+	EarliestStart  time.Time
+	Deadline       time.Time
+	Location       string
+	DayStart       time.Duration
+	DayEnd         time.Duration
+	PreferredStart time.Duration
+	PreferredEnd   time.Duration
+	Limit          int
+}
+
+type SlotRecommendation struct {
+	StartTime          time.Time   `json:"startTime"`
+	EndTime            time.Time   `json:"endTime"`
+	Score              float64     `json:"score"`
+	AvailableCount     int         `json:"availableCount"`
+	TotalCount         int         `json:"totalCount"`
+	AvailableUserIds   []uuid.UUID `json:"availableUserIds"`
+	UnavailableUserIds []uuid.UUID `json:"unavailableUserIds"`
+	AvailabilityScore  float64     `json:"availabilityScore"`
+	TimeOfDayScore     float64     `json:"timeOfDayScore"`
+	ProximityScore     float64     `json:"proximityScore"`
+	FairnessScore      float64     `json:"fairnessScore"`
+	SuggestedLocation  string      `json:"suggestedLocation"`
+}
@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MeetingPoll lets an organizer propose several times for a meeting and have
+// the invitees vote before one is picked. Finalizing turns the chosen option
+// into ScheduleId.
+type MeetingPoll struct {
+	Id            uuid.UUID   `gorm:"primaryKey" json:"id"`
+	UserId        uuid.UUID   `gorm:"not null;index" json:"userId"` // organizer
+	Title         string      `gorm:"not null" json:"title"`
+	Description   string      `gorm:"not null" json:"description"`
+	Location      string      `gorm:"not null" json:"location"`
+	RoomId        *uuid.UUID  `json:"roomId"`
+	Category      string      `gorm:"not null" json:"category"`
+	CategoryId    *uuid.UUID  `json:"categoryId"`
+	Visibility    string      `gorm:"not null" json:"visibility"`
+	InviteeIds    []uuid.UUID `gorm:"type:text;serializer:json" json:"inviteeIds"`
+	Status        string      `gorm:"not null;default:Open" json:"status"`
+	FinalOptionId *uuid.UUID  `json:"finalOptionId"`
+	ScheduleId    *uuid.UUID  `json:"scheduleId"`
+	CreatedAt     time.Time   `json:"createdAt"`
+}
+
+const (
+	MeetingPollOpen      = "Open"
+	MeetingPollFinalized = "Finalized"
+	MeetingPollCancelled = "Cancelled"
+)
+
+type MeetingPollOption struct {
+	Id        uuid.UUID `gorm:"primaryKey" json:"id"`
+	PollId    uuid.UUID `gorm:"not null;index" json:"pollId"`
+	StartTime time.Time `gorm:"not null" json:"startTime"`
+	EndTime   time.Time `gorm:"not null" json:"endTime"`
+}
+
+// MeetingPollVote is one invitee's answer for one option.
+type MeetingPollVote struct {
+	Id       uuid.UUID `gorm:"primaryKey" json:"id"`
+	PollId   uuid.UUID `gorm:"not null;index" json:"pollId"`
+	OptionId uuid.UUID `gorm:"not null;uniqueIndex:idx_meeting_poll_vote" json:"optionId"`
+	UserId   uuid.UUID `gorm:"not null;uniqueIndex:idx_meeting_poll_vote" json:"userId"`
+	Answer   string    `gorm:"not null" json:"answer"`
+}
+
+const (
+	VoteYes   = "yes"
+	VoteMaybe = "maybe"
+	VoteNo    = "no"
+)
+
+type MeetingPollResponse struct {
+	Poll    MeetingPoll                `json:"poll"`
+	Options []MeetingPollOptionSummary `json:"options"`
+}
+
+// MeetingPollOptionSummary tallies the votes for an option. Invitees who have
+// not answered are in Pending.
+type MeetingPollOptionSummary struct {
+	Option  MeetingPollOption `json:"option"`
+	Yes     []uuid.UUID       `json:"yes"`
+	Maybe   []uuid.UUID       `json:"maybe"`
+	No      []uuid.UUID       `json:"no"`
+	Pending []uuid.UUID       `json:"pending"`
+}
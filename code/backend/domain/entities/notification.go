@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Notification struct {
+	Id         uuid.UUID  `gorm:"primaryKey" json:"id"`
+	UserId     uuid.UUID  `gorm:"not null;index" json:"userId"`
+	ScheduleId *uuid.UUID `json:"scheduleId"`
+	Type       string     `gorm:"not null" json:"type"`
+	Title      string     `gorm:"not null" json:"title"`
+	Message    string     `gorm:"not null" json:"message"`
+	CreatedAt  time.Time  `gorm:"not null" json:"createdAt"`
+	ReadAt     *time.Time `json:"readAt"`
+}
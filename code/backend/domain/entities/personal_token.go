@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PersonalToken struct {
+	Id         uuid.UUID  `gorm:"primaryKey" json:"id"`
+	UserId     uuid.UUID  `gorm:"not null" json:"userId"`
+	Name       string     `gorm:"not null" json:"name"`
+	TokenHash  string     `gorm:"not null;uniqueIndex;size:64" json:"-"`
+	CreatedAt  time.Time  `gorm:"not null" json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+}
+
+type PersonalTokenResponse struct {
+	PersonalToken PersonalToken `json:"personalToken"`
+	Token         string        `json:"token"`
+}
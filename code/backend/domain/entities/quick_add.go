@@ -0,0 +1,18 @@
+package entities
+
+import "github.com/google/uuid"
+
+// QuickAddDraft is a schedule read from one line of text. Nothing is saved; the
+// client shows the draft, lets the user fix it and then creates it as usual.
+type QuickAddDraft struct {
+	Schedule        Schedule              `json:"schedule"`
+	Participants    []QuickAddParticipant `json:"participants"`
+	UnresolvedNames []string              `json:"unresolvedNames"` // names matching no followed user, or several
+	NewCategory     bool                  `json:"newCategory"`     // the #tag is not one of the user's categories yet
+}
+
+type QuickAddParticipant struct {
+	UserId uuid.UUID `json:"userId"`
+	Name   string    `json:"name"`
+	Input  string    `json:"input"` // the name as typed
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reminder is a user's reminder offset. With a ScheduleId it applies to that
+// schedule only; without one it is the user's default for every schedule.
+type Reminder struct {
+	Id            uuid.UUID  `gorm:"primaryKey" json:"id"`
+	UserId        uuid.UUID  `gorm:"not null;index" json:"userId"`
+	ScheduleId    *uuid.UUID `gorm:"index" json:"scheduleId"`
+	OffsetMinutes int        `gorm:"not null" json:"offsetMinutes"`
+	Channel       string     `gorm:"not null;default:InApp" json:"channel"`
+}
+
+// ReminderDelivery records one reminder for one occurrence. Its Id is derived
+// from the schedule, recipient, offset, channel and start time, so inserting it
+// doubles as claiming the delivery.
+type ReminderDelivery struct {
+	Id            uuid.UUID  `gorm:"primaryKey" json:"id"`
+	ScheduleId    uuid.UUID  `gorm:"not null;index" json:"scheduleId"`
+	UserId        uuid.UUID  `gorm:"not null" json:"userId"`
+	Channel       string     `gorm:"not null" json:"channel"`
+	OffsetMinutes int        `gorm:"not null" json:"offsetMinutes"`
+	FireAt        time.Time  `gorm:"not null" json:"fireAt"`
+	Status        string     `gorm:"not null;default:Sending;index" json:"status"`
+	Attempts      int        `gorm:"not null" json:"attempts"`
+	ClaimedAt     time.Time  `gorm:"not null" json:"claimedAt"`
+	SentAt        *time.Time `json:"sentAt"`
+	LastError     string     `gorm:"not null" json:"lastError"`
+}
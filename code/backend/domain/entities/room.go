@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Room struct {
+	Id       uuid.UUID `gorm:"primaryKey" json:"id"`
+	Name     string    `gorm:"not null;uniqueIndex;size:191" json:"name"`
+	Building string    `gorm:"not null" json:"building"`
+	Capacity int       `gorm:"not null" json:"capacity"`
+	Features []string  `gorm:"type:text;serializer:json" json:"features"`
+}
+
+// RoomQuery searches for rooms that are free for the whole of [StartTime, EndTime).
+type RoomQuery struct {
+	StartTime   time.Time
+	EndTime     time.Time
+	MinCapacity int
+	Building    string
+	Features    []string
+}
@@ -4,28 +4,62 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Schedule struct {
-	Id          uuid.UUID `gorm:"primaryKey" json:"id"`
-	UserId      uuid.UUID `gorm:"not null" json:"userId"`
-	StartTime   time.Time `gorm:"not null" json:"startTime"`
-	EndTime     time.Time `gorm:"not null" json:"endTime"`
-	Title       string    `gorm:"not null" json:"title"`
-	Description string    `gorm:"not null" json:"description"`
-	Location    string    `gorm:"not null" json:"location"`
-	Category    string    `gorm:"not null" json:"category"`
+	Id          uuid.UUID      `gorm:"primaryKey" json:"id"`
+	UserId      uuid.UUID      `gorm:"not null" json:"userId"`
+	StartTime   time.Time      `gorm:"not null" json:"startTime"`
+	EndTime     time.Time      `gorm:"not null" json:"endTime"`
+	IsAllDay    bool           `gorm:"not null;default:false" json:"isAllDay"`
+	Title       string         `gorm:"not null" json:"title"`
+	Description string         `gorm:"not null" json:"description"`
+	Location    string         `gorm:"not null" json:"location"` // name of RoomId when a room is booked
+	Category    string         `gorm:"not null" json:"category"` // name of CategoryId, kept for older clients
+	CategoryId  *uuid.UUID     `gorm:"index" json:"categoryId"`
+	RoomId      *uuid.UUID     `gorm:"index" json:"roomId"`
+	Visibility  string         `gorm:"not null;default:busy" json:"visibility"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deletedAt"` // set while the schedule is in the trash
+	Version     int            `gorm:"not null;default:1" json:"version"`
 	// RecurringUntil *time.Time `json:"recurringUntil"` // opsional, nullable
-	// Color       string    `json:"color"`
 }
 
+// AfterFind keeps all-day schedules on UTC midnights. An all-day schedule covers
+// the dates [StartTime, EndTime) with EndTime exclusive, and clients should read
+// only the date part, so the database connection's zone must not shift it.
+func (s *Schedule) AfterFind(tx *gorm.DB) error {
+	if s.IsAllDay {
+		s.StartTime = s.StartTime.UTC()
+		s.EndTime = s.EndTime.UTC()
+	}
+
+	return nil
+}
+
+// Visibility levels, from most to least restrictive. Busy shows followers only
+// the time slot; details shows followers everything; public shows everyone.
+const (
+	VisibilityPrivate = "private"
+	VisibilityBusy    = "busy"
+	VisibilityDetails = "details"
+	VisibilityPublic  = "public"
+)
+
 type ScheduleParticipant struct {
 	Id         uuid.UUID `gorm:"primaryKey" json:"id"`
 	ScheduleId uuid.UUID `gorm:"not null" json:"scheduleId"`
 	UserId     uuid.UUID `gorm:"not null" json:"userId"`
 	Status     string    `gorm:"not null;default:Pending" json:"status"`
+	Role       string    `gorm:"not null;default:Attendee" json:"role"`
 }
 
+// Participant roles. Co-organizers may edit the schedule alongside its owner.
+const (
+	ParticipantRoleAttendee    = "Attendee"
+	ParticipantRoleCoOrganizer = "CoOrganizer"
+)
+
 type ScheduleParticipantResponse struct {
 	Schedule Schedule `json:"schedule"`
 	User     User     `json:"user"`
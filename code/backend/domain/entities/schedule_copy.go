@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleCopyRequest copies schedules by Offset or, when TargetWeek is set, to
+// the same weekday and time in the Monday-to-Sunday week containing TargetWeek.
+// With a RecipientId the copies also invite that user instead of only landing
+// in the requester's calendar.
+type ScheduleCopyRequest struct {
+	UserId      uuid.UUID
+	ScheduleIds []uuid.UUID
+	Offset      time.Duration
+	TargetWeek  *time.Time
+	RecipientId *uuid.UUID
+}
+
+type ScheduleCopyResult struct {
+	Copies []ScheduleCopy `json:"copies"`
+}
+
+type ScheduleCopy struct {
+	SourceId       uuid.UUID   `json:"sourceId"`
+	Schedule       Schedule    `json:"schedule"`
+	ParticipantIds []uuid.UUID `json:"participantIds"`
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScheduleProposal is a participant suggesting another time for a schedule.
+// Each participant has at most one Pending proposal per schedule; a new one
+// supersedes it.
+type ScheduleProposal struct {
+	Id            uuid.UUID `gorm:"primaryKey" json:"id"`
+	ScheduleId    uuid.UUID `gorm:"not null;index" json:"scheduleId"`
+	ParticipantId uuid.UUID `gorm:"not null" json:"participantId"`
+	UserId        uuid.UUID `gorm:"not null" json:"userId"`
+	StartTime     time.Time `gorm:"not null" json:"startTime"`
+	EndTime       time.Time `gorm:"not null" json:"endTime"`
+	Note          string    `gorm:"not null" json:"note"`
+	Status        string    `gorm:"not null;default:Pending" json:"status"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+const (
+	ProposalPending    = "Pending"
+	ProposalAccepted   = "Accepted"
+	ProposalDeclined   = "Declined"
+	ProposalSuperseded = "Superseded" // replaced by a newer proposal, or another one was accepted
+)
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RevisionCreated  = "Created"
+	RevisionUpdated  = "Updated"
+	RevisionDeleted  = "Deleted"
+	RevisionRestored = "Restored"
+	RevisionReverted = "Reverted"
+)
+
+// ScheduleRevision records one change to a schedule. Snapshot is the schedule as
+// it was right after the change (right before it, for a delete), which is what a
+// revert goes back to.
+type ScheduleRevision struct {
+	Id         uuid.UUID     `gorm:"primaryKey" json:"id"`
+	ScheduleId uuid.UUID     `gorm:"not null;index" json:"scheduleId"`
+	Action     string        `gorm:"not null" json:"action"`
+	ChangedBy  uuid.UUID     `gorm:"not null" json:"changedBy"`
+	ChangedAt  time.Time     `gorm:"not null" json:"changedAt"`
+	Changes    []FieldChange `gorm:"type:text;serializer:json" json:"changes"`
+	Snapshot   Schedule      `gorm:"type:text;serializer:json" json:"snapshot"`
+}
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
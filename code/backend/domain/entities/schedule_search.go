@@ -0,0 +1,37 @@
+package entities
+
+import "github.com/google/uuid"
+
+type ScheduleSearchQuery struct {
+	UserId uuid.UUID
+	Query  string
+	Limit  int
+}
+
+// ScheduleSearchHit is one matching schedule. Score is only comparable within
+// the same search.
+type ScheduleSearchHit struct {
+	Schedule   Schedule          `json:"schedule"`
+	Score      float64           `json:"score"`
+	Highlights []SearchHighlight `json:"highlights"`
+}
+
+// SearchHighlight marks where the search terms occur in a field. Ranges are
+// rune offsets into Snippet, which is the whole field or, for long
+// descriptions, the part around the first match.
+type SearchHighlight struct {
+	Field   string      `json:"field"`
+	Snippet string      `json:"snippet"`
+	Ranges  []TextRange `json:"ranges"`
+}
+
+type TextRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+type ScheduleSearchResult struct {
+	Query string              `json:"query"`
+	Terms []string            `json:"terms"`
+	Hits  []ScheduleSearchHit `json:"hits"`
+}
@@ -0,0 +1,26 @@
+package entities
+
+import "github.com/google/uuid"
+
+// ScheduleTemplate holds the defaults for a kind of event a user creates often,
+// such as "Study group, 2h at Library, invite X and Y".
+type ScheduleTemplate struct {
+	Id              uuid.UUID          `gorm:"primaryKey" json:"id"`
+	UserId          uuid.UUID          `gorm:"not null;index" json:"userId"`
+	Name            string             `gorm:"not null" json:"name"`
+	Title           string             `gorm:"not null" json:"title"`
+	Description     string             `gorm:"not null" json:"description"`
+	Location        string             `gorm:"not null" json:"location"`
+	RoomId          *uuid.UUID         `json:"roomId"`
+	Category        string             `gorm:"not null" json:"category"`
+	CategoryId      *uuid.UUID         `json:"categoryId"`
+	Visibility      string             `gorm:"not null" json:"visibility"`
+	DurationMinutes int                `gorm:"not null" json:"durationMinutes"`
+	ParticipantIds  []uuid.UUID        `gorm:"type:text;serializer:json" json:"participantIds"`
+	Reminders       []TemplateReminder `gorm:"type:text;serializer:json" json:"reminders"`
+}
+
+type TemplateReminder struct {
+	OffsetMinutes int    `json:"offsetMinutes"`
+	Channel       string `json:"channel"`
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TaskPriorityLow    = "Low"
+	TaskPriorityMedium = "Medium"
+	TaskPriorityHigh   = "High"
+)
+
+// Task is a deadline rather than a time block. Course and ScheduleId optionally
+// tie it to a class, e.g. an assignment for "Software Engineering".
+type Task struct {
+	Id               uuid.UUID  `gorm:"primaryKey" json:"id"`
+	UserId           uuid.UUID  `gorm:"not null;index" json:"userId"`
+	Title            string     `gorm:"not null" json:"title"`
+	Description      string     `gorm:"not null" json:"description"`
+	DueDate          time.Time  `gorm:"not null;index" json:"dueDate"`
+	Priority         string     `gorm:"not null;default:Medium" json:"priority"`
+	EstimatedMinutes int        `gorm:"not null" json:"estimatedMinutes"`
+	IsCompleted      bool       `gorm:"not null" json:"isCompleted"`
+	CompletedAt      *time.Time `json:"completedAt"`
+	Course           string     `gorm:"not null" json:"course"`
+	ScheduleId       *uuid.UUID `gorm:"index" json:"scheduleId"`
+}
+
+// TaskQuery filters a user's tasks. Zero values mean "any".
+type TaskQuery struct {
+	UserId     uuid.UUID
+	Completed  *bool
+	DueFrom    *time.Time
+	DueTo      *time.Time
+	Priority   string
+	Course     string
+	ScheduleId *uuid.UUID
+}
+
+// CalendarView is everything a user's calendar shows for a period.
+type CalendarView struct {
+	Schedules []Schedule `json:"schedules"`
+	Tasks     []Task     `json:"tasks"`
+}
@@ -12,8 +12,17 @@ type User struct {
 	Major          string    `gorm:"not null" json:"major"`
 	ProfilePicture string    `gorm:"not null" json:"profilePicture"`
 	IsActive       bool      `gorm:"not null" json:"isActive"`
+	Version        int       `gorm:"not null;default:1" json:"version"`
 }
 
+const (
+	// RoleAdmin may manage shared data such as the academic calendar.
+	RoleAdmin = "Admin"
+	// RoleUser is given to everyone who registers. Roles are never taken from
+	// the client.
+	RoleUser = "User"
+)
+
 type UserFollowResponse struct {
 	User             User   `json:"user"`
 	Follower         []User `json:"follower"`
@@ -30,4 +39,5 @@ type UserFrontendResponse struct {
 	Major          string `json:"major"`
 	ProfilePicture string `json:"profilePicture"`
 	IsActive       bool   `json:"isActive"`
+	Version        int    `json:"version"`
 }
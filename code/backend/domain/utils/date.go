@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	scheduleLocation     *time.Location
+	scheduleLocationOnce sync.Once
+)
+
+// DateOf returns t's calendar date, read in t's own location, as a UTC midnight.
+func DateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// IsMidnight reports whether t falls exactly on the start of a day in its location.
+func IsMidnight(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
+
+// LocalDay turns a date stored as a UTC midnight into the same midnight in loc.
+func LocalDay(date time.Time, loc *time.Location) time.Time {
+	date = date.UTC()
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+}
+
+// ScheduleLocation is the zone schedule times are written in. Schedules store
+// wall-clock times without an offset (see the seeders), so SCHEDULE_TIMEZONE tells
+// the server how to line them up with the real clock.
+func ScheduleLocation() *time.Location {
+	scheduleLocationOnce.Do(func() {
+		name := os.Getenv("SCHEDULE_TIMEZONE")
+		if name == "" {
+			name = "Asia/Jakarta"
+		}
+
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			log.Printf("Unknown SCHEDULE_TIMEZONE %q, using UTC\n", name)
+			loc = time.UTC
+		}
+		scheduleLocation = loc
+	})
+
+	return scheduleLocation
+}
+
+// WallClock writes t's clock reading in its own location as a UTC value, the form
+// schedule times are stored in.
+func WallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// WallClockNow is the current time in loc written as a wall-clock value in UTC.
+func WallClockNow(loc *time.Location) time.Time {
+	return WallClock(time.Now().In(loc))
+}
+
+// FormatScheduleTime writes a stored schedule time for people to read, e.g. in
+// notifications and emails. Schedule times are wall-clock values held in UTC, so
+// they are read in UTC whatever zone the database driver returned them in.
+func FormatScheduleTime(t time.Time) string {
+	return t.UTC().Format("Mon, 02 Jan 2006 15:04")
+}
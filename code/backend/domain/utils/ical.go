@@ -0,0 +1,320 @@
+package utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	ICalDateTimeFormat      = "20060102T150405Z"
+	ICalLocalDateTimeFormat = "20060102T150405"
+	ICalDateFormat          = "20060102"
+)
+
+var ErrInvalidICal = errors.New("invalid iCalendar data")
+
+type ICalProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+type ICalComponent struct {
+	Name       string
+	Properties []ICalProperty
+	Components []ICalComponent
+}
+
+// Get returns the first property with the given name.
+func (c ICalComponent) Get(name string) (ICalProperty, bool) {
+	for _, prop := range c.Properties {
+		if prop.Name == name {
+			return prop, true
+		}
+	}
+
+	return ICalProperty{}, false
+}
+
+// Add appends a property, escaping nothing; callers escape TEXT values with EscapeICalText.
+func (c *ICalComponent) Add(name string, value string) {
+	c.Properties = append(c.Properties, ICalProperty{Name: name, Value: value})
+}
+
+func (c *ICalComponent) AddWithParams(name string, params map[string]string, value string) {
+	c.Properties = append(c.Properties, ICalProperty{Name: name, Params: params, Value: value})
+}
+
+// Find returns every direct child component with the given name.
+func (c ICalComponent) Find(name string) []ICalComponent {
+	found := make([]ICalComponent, 0)
+	for _, child := range c.Components {
+		if child.Name == name {
+			found = append(found, child)
+		}
+	}
+
+	return found
+}
+
+// Encode serializes the component as CRLF-terminated, folded content lines.
+func (c ICalComponent) Encode() string {
+	var b strings.Builder
+	c.encode(&b)
+	return b.String()
+}
+
+func (c ICalComponent) encode(b *strings.Builder) {
+	writeContentLine(b, "BEGIN:"+c.Name)
+	for _, prop := range c.Properties {
+		line := prop.Name
+		keys := make([]string, 0, len(prop.Params))
+		for key := range prop.Params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := prop.Params[key]
+			if strings.ContainsAny(value, ":;,") {
+				value = `"` + value + `"`
+			}
+			line += ";" + key + "=" + value
+		}
+		writeContentLine(b, line+":"+prop.Value)
+	}
+	for _, child := range c.Components {
+		child.encode(b)
+	}
+	writeContentLine(b, "END:"+c.Name)
+}
+
+// writeContentLine folds lines longer than 75 octets as required by RFC 5545.
+func writeContentLine(b *strings.Builder, line string) {
+	for len(line) > 75 {
+		cut := 75
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
+
+// ParseICal parses a single top-level component (usually VCALENDAR).
+func ParseICal(data string) (ICalComponent, error) {
+	lines := unfoldLines(data)
+	if len(lines) == 0 {
+		return ICalComponent{}, ErrInvalidICal
+	}
+
+	stack := make([]ICalComponent, 0)
+	var root *ICalComponent
+
+	for _, line := range lines {
+		prop, err := parseContentLine(line)
+		if err != nil {
+			return ICalComponent{}, err
+		}
+
+		switch prop.Name {
+		case "BEGIN":
+			stack = append(stack, ICalComponent{Name: strings.ToUpper(prop.Value)})
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(prop.Value) {
+				return ICalComponent{}, fmt.Errorf("%w: unexpected END:%s", ErrInvalidICal, prop.Value)
+			}
+			done := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				root = &done
+			} else {
+				parent := &stack[len(stack)-1]
+				parent.Components = append(parent.Components, done)
+			}
+		default:
+			if len(stack) == 0 {
+				return ICalComponent{}, fmt.Errorf("%w: property outside component", ErrInvalidICal)
+			}
+			current := &stack[len(stack)-1]
+			current.Properties = append(current.Properties, prop)
+		}
+
+		if root != nil {
+			break
+		}
+	}
+
+	if root == nil {
+		return ICalComponent{}, fmt.Errorf("%w: unterminated component", ErrInvalidICal)
+	}
+
+	return *root, nil
+}
+
+func unfoldLines(data string) []string {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func parseContentLine(line string) (ICalProperty, error) {
+	inQuotes := false
+	colon := -1
+	for i := 0; i < len(line); i++ {
+		if line[i] == '"' {
+			inQuotes = !inQuotes
+		}
+		if line[i] == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return ICalProperty{}, fmt.Errorf("%w: missing ':' in %q", ErrInvalidICal, line)
+	}
+
+	head := strings.Split(line[:colon], ";")
+	prop := ICalProperty{
+		Name:   strings.ToUpper(head[0]),
+		Params: map[string]string{},
+		Value:  line[colon+1:],
+	}
+	for _, param := range head[1:] {
+		key, value, found := strings.Cut(param, "=")
+		if !found {
+			continue
+		}
+		prop.Params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+
+	return prop, nil
+}
+
+func EscapeICalText(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(s)
+}
+
+func UnescapeICalText(s string) string {
+	replacer := strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+	return replacer.Replace(s)
+}
+
+// FormatICalDateTime writes an instant in UTC, for stamps such as DTSTAMP.
+func FormatICalDateTime(t time.Time) string {
+	return t.UTC().Format(ICalDateTimeFormat)
+}
+
+// FormatICalLocalDateTime writes a stored wall-clock time as a floating
+// date-time, so clients show the same clock reading whatever their zone.
+func FormatICalLocalDateTime(t time.Time) string {
+	return t.UTC().Format(ICalLocalDateTimeFormat)
+}
+
+// ParseICalDateTime understands UTC, floating and TZID date-times as well as
+// VALUE=DATE values, and returns them as wall-clock times in loc held in UTC,
+// the form schedule times are stored in. Floating values and unknown TZIDs are
+// taken as already written in loc. The second return value reports whether the
+// value was a date.
+func ParseICalDateTime(prop ICalProperty, loc *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.Value)
+
+	if prop.Params["VALUE"] == "DATE" || len(value) == len(ICalDateFormat) {
+		t, err := time.ParseInLocation(ICalDateFormat, value, time.UTC)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(ICalDateTimeFormat, value)
+		return WallClock(t.In(loc)), false, err
+	}
+
+	if tzid, ok := prop.Params["TZID"]; ok {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			t, err := time.ParseInLocation(ICalLocalDateTimeFormat, value, zone)
+			return WallClock(t.In(loc)), false, err
+		}
+	}
+
+	t, err := time.ParseInLocation(ICalLocalDateTimeFormat, value, time.UTC)
+	return t, false, err
+}
+
+// ParseICalDuration parses the subset of RFC 5545 durations clients send in practice,
+// e.g. PT1H30M, P1D or -PT15M.
+func ParseICalDuration(value string) (time.Duration, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+		s = s[1:]
+	}
+	s = strings.TrimPrefix(s, "+")
+	if !strings.HasPrefix(s, "P") {
+		return 0, fmt.Errorf("%w: bad duration %q", ErrInvalidICal, value)
+	}
+	s = s[1:]
+
+	var total time.Duration
+	inTime := false
+	number := 0
+	hasNumber := false
+	for _, r := range s {
+		switch {
+		case r == 'T':
+			inTime = true
+		case r >= '0' && r <= '9':
+			number = number*10 + int(r-'0')
+			hasNumber = true
+		default:
+			if !hasNumber {
+				return 0, fmt.Errorf("%w: bad duration %q", ErrInvalidICal, value)
+			}
+			unit := time.Duration(0)
+			switch {
+			case r == 'W':
+				unit = 7 * 24 * time.Hour
+			case r == 'D':
+				unit = 24 * time.Hour
+			case r == 'H' && inTime:
+				unit = time.Hour
+			case r == 'M' && inTime:
+				unit = time.Minute
+			case r == 'S' && inTime:
+				unit = time.Second
+			default:
+				return 0, fmt.Errorf("%w: bad duration %q", ErrInvalidICal, value)
+			}
+			total += time.Duration(number) * unit
+			number = 0
+			hasNumber = false
+		}
+	}
+
+	return sign * total, nil
+}
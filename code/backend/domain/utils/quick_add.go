@@ -0,0 +1,654 @@
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuickAdd is what ParseQuickAdd understood from a line of text. Names and
+// Category are returned as written; resolving them is up to the caller.
+type QuickAdd struct {
+	Title    string
+	Start    time.Time
+	End      time.Time
+	IsAllDay bool
+	Location string
+	Category string
+	Names    []string
+}
+
+var (
+	clockPattern    = regexp.MustCompile(`^(\d{1,2})(?:[:.](\d{2}))?(am|pm)?$`)
+	durationPattern = regexp.MustCompile(`^(\d+(?:[.,]\d+)?)([a-z]*)$`)
+	numberPattern   = regexp.MustCompile(`^\d+$`)
+)
+
+var weekdayWords = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday, "minggu": time.Sunday, "ahad": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday, "senin": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday, "selasa": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday, "rabu": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday, "kamis": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday, "jumat": time.Friday, "jum'at": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday, "sabtu": time.Saturday,
+}
+
+var monthWords = map[string]time.Month{
+	"january": time.January, "jan": time.January, "januari": time.January,
+	"february": time.February, "feb": time.February, "februari": time.February,
+	"march": time.March, "mar": time.March, "maret": time.March,
+	"april": time.April, "apr": time.April,
+	"may": time.May, "mei": time.May,
+	"june": time.June, "jun": time.June, "juni": time.June,
+	"july": time.July, "jul": time.July, "juli": time.July,
+	"august": time.August, "aug": time.August, "agustus": time.August, "agu": time.August, "agt": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October, "oktober": time.October, "okt": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December, "desember": time.December, "des": time.December,
+}
+
+// Default start times, as hours, for a part of the day given without a time.
+var partOfDayWords = map[string]int{
+	"morning": 9, "pagi": 9,
+	"afternoon": 15, "siang": 13, "sore": 16,
+	"evening": 19, "night": 19, "malam": 19,
+}
+
+var namedTimes = map[string]int{"noon": 12, "midday": 12, "midnight": 0}
+
+var (
+	timePrefixes     = []string{"at", "from", "jam", "pukul", "@"}
+	rangeConnectors  = []string{"-", "to", "until", "till", "sampai", "hingga", "s/d"}
+	datePrefixes     = []string{"on", "pada", "hari", "tanggal", "tgl"}
+	durationPrefixes = []string{"for", "selama"}
+	nameTriggers     = []string{"with", "dengan", "bersama"}
+	locationTriggers = []string{"at", "in", "di", "@"}
+	phraseStops      = []string{"at", "in", "di", "@", "on", "pada", "for", "selama", "with", "dengan", "bersama", "about", "tentang", "untuk"}
+	titleFillers     = []string{"on", "pada", "at", "from", "jam", "pukul", "hari", "di", "in", "@"}
+)
+
+// ParseQuickAdd reads a one-line event description in English or Indonesian,
+// e.g. "Study with Alice tomorrow 3-5pm at Room 101 #Study" or "Rapat dengan
+// Budi besok jam 3 sore di Lab AI". Relative dates are taken from now and the
+// result is in now's location. Without a time the event is all-day; without a
+// date it is today, or tomorrow if that time has already passed today.
+func ParseQuickAdd(text string, now time.Time) QuickAdd {
+	p := newQuickAddParser(text, now)
+
+	p.hashtags()
+	p.times()
+	p.dates()
+	p.partOfDay()
+	p.durations()
+	names := p.phrase(nameTriggers)
+	location := p.phrase(locationTriggers)
+
+	result := QuickAdd{
+		Title:    p.title(),
+		Location: location,
+		Category: p.category,
+		Names:    splitNames(names),
+	}
+	p.schedule(&result)
+
+	return result
+}
+
+type quickAddParser struct {
+	words []string
+	lower []string
+	used  []bool
+	now   time.Time
+
+	category     string
+	date         *time.Time
+	start        *time.Duration
+	end          *time.Duration
+	duration     time.Duration
+	defaultStart *time.Duration
+}
+
+func newQuickAddParser(text string, now time.Time) *quickAddParser {
+	text = strings.NewReplacer("–", "-", "—", "-").Replace(text)
+	words := strings.Fields(text)
+
+	p := &quickAddParser{
+		words: words,
+		lower: make([]string, len(words)),
+		used:  make([]bool, len(words)),
+		now:   now,
+	}
+	for i, word := range words {
+		lower := strings.ToLower(strings.TrimRight(word, ",!?;"))
+		lower = strings.ReplaceAll(strings.ReplaceAll(lower, "a.m.", "am"), "p.m.", "pm")
+		p.lower[i] = strings.TrimSuffix(lower, ".")
+	}
+
+	return p
+}
+
+func (p *quickAddParser) free(i int) bool {
+	return i >= 0 && i < len(p.words) && !p.used[i]
+}
+
+func (p *quickAddParser) is(i int, options ...string) bool {
+	if !p.free(i) {
+		return false
+	}
+	for _, option := range options {
+		if p.lower[i] == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *quickAddParser) consume(from int, to int) {
+	for i := from; i < to; i++ {
+		p.used[i] = true
+	}
+}
+
+func (p *quickAddParser) hashtags() {
+	for i, word := range p.lower {
+		if strings.HasPrefix(word, "#") && len(word) > 1 {
+			if p.category == "" {
+				p.category = strings.TrimRight(p.words[i][1:], ",.!?;")
+			}
+			p.used[i] = true
+		}
+	}
+}
+
+type clock struct {
+	hour     int
+	minute   int
+	meridiem string // "am", "pm", or an Indonesian part of the day
+	explicit bool   // has minutes, a meridiem or a part of the day
+}
+
+// readClock reads "3", "3pm", "3 pm", "15:00", "15.30" or "3 sore" starting at i.
+func (p *quickAddParser) readClock(word string, i int) (clock, int, bool) {
+	m := clockPattern.FindStringSubmatch(word)
+	if m == nil {
+		return clock{}, i, false
+	}
+
+	c := clock{meridiem: m[3]}
+	c.hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		c.minute, _ = strconv.Atoi(m[2])
+	}
+
+	next := i + 1
+	if c.meridiem == "" && p.is(next, "am", "pm", "pagi", "siang", "sore", "malam") {
+		c.meridiem = p.lower[next]
+		next++
+	}
+	c.explicit = m[2] != "" || c.meridiem != ""
+
+	if c.hour > 24 || c.minute > 59 || (c.meridiem != "" && c.meridiem != "malam" && c.hour > 12) {
+		return clock{}, i, false
+	}
+
+	return c, next, true
+}
+
+// hour24 converts a clock to a 24-hour hour. A bare 1-7 after "at" or "jam" is
+// read as afternoon, which is what students almost always mean.
+func (c clock) hour24(prefixed bool) int {
+	h := c.hour
+	switch c.meridiem {
+	case "am", "pagi":
+		if h == 12 {
+			h = 0
+		}
+	case "pm", "sore":
+		if h < 12 {
+			h += 12
+		}
+	case "siang":
+		if h >= 1 && h <= 5 {
+			h += 12
+		}
+	case "malam":
+		if h >= 6 && h < 12 {
+			h += 12
+		} else if h == 12 {
+			h = 0
+		}
+	case "":
+		if prefixed && !c.explicit && h >= 1 && h <= 7 {
+			h += 12
+		}
+	}
+
+	return h % 24
+}
+
+func (p *quickAddParser) times() {
+	for i := 0; i < len(p.words) && p.start == nil; i++ {
+		if !p.free(i) {
+			continue
+		}
+
+		j := i
+		prefixed := p.is(j, timePrefixes...)
+		if prefixed {
+			j++
+		}
+		if !p.free(j) {
+			continue
+		}
+
+		if hour, ok := namedTimes[p.lower[j]]; ok {
+			start := time.Duration(hour) * time.Hour
+			p.start = &start
+			p.consume(i, j+1)
+			continue
+		}
+
+		first, second, hasSecond, next, ok := p.readRange(j)
+		if !ok || !(first.explicit || second.explicit || prefixed) {
+			continue
+		}
+
+		startHour := first.hour24(prefixed)
+		start := time.Duration(startHour)*time.Hour + time.Duration(first.minute)*time.Minute
+		p.start = &start
+
+		if hasSecond {
+			endHour := second.hour24(prefixed)
+			// "3-5pm": the start borrows the end's afternoon when that keeps it before the end.
+			if first.meridiem == "" && first.hour < 12 && first.hour+12 < endHour && second.meridiem != "" {
+				start += 12 * time.Hour
+				p.start = &start
+			}
+
+			end := time.Duration(endHour)*time.Hour + time.Duration(second.minute)*time.Minute
+			if end <= start {
+				end += 24 * time.Hour
+			}
+			p.end = &end
+		}
+
+		p.consume(i, next)
+	}
+}
+
+// readRange reads a single clock or a range written as "3-5pm", "3pm - 5pm",
+// "15.00 sampai 17.00" or "from 3 to 5pm".
+func (p *quickAddParser) readRange(j int) (clock, clock, bool, int, bool) {
+	if parts := strings.Split(p.lower[j], "-"); len(parts) == 2 && parts[1] != "" {
+		first, _, ok1 := p.readClock(parts[0], j)
+		second, next, ok2 := p.readClock(parts[1], j)
+		if ok1 && ok2 {
+			return first, second, true, next, true
+		}
+		return clock{}, clock{}, false, j, false
+	}
+
+	first, next, ok := p.readClock(p.lower[j], j)
+	if !ok {
+		return clock{}, clock{}, false, j, false
+	}
+
+	if p.is(next, rangeConnectors...) && p.free(next+1) {
+		if second, after, ok := p.readClock(p.lower[next+1], next+1); ok {
+			return first, second, true, after, true
+		}
+	}
+
+	return first, clock{}, false, next, true
+}
+
+func (p *quickAddParser) dates() {
+	for i := 0; i < len(p.words) && p.date == nil; i++ {
+		if !p.free(i) {
+			continue
+		}
+
+		j := i
+		if p.is(j, datePrefixes...) && p.free(j+1) {
+			j++
+		}
+
+		date, next, ok := p.readDate(j)
+		if !ok {
+			continue
+		}
+
+		p.date = &date
+		p.consume(i, next)
+	}
+}
+
+func (p *quickAddParser) today() time.Time {
+	return time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+}
+
+func (p *quickAddParser) evening() {
+	if p.defaultStart == nil {
+		evening := time.Duration(partOfDayWords["evening"]) * time.Hour
+		p.defaultStart = &evening
+	}
+}
+
+// readDate recognises a date phrase at j and returns the date and the index after it.
+func (p *quickAddParser) readDate(j int) (time.Time, int, bool) {
+	today := p.today()
+	word := p.lower[j]
+
+	switch {
+	case word == "today" || (word == "hari" && p.is(j+1, "ini")):
+		if word == "hari" {
+			return today, j + 2, true
+		}
+		return today, j + 1, true
+	case word == "tonight" || (word == "nanti" && p.is(j+1, "malam")) || (word == "malam" && p.is(j+1, "ini")):
+		p.evening()
+		if word == "tonight" {
+			return today, j + 1, true
+		}
+		return today, j + 2, true
+	case word == "tomorrow" || word == "tmr" || word == "tmrw" || word == "besok" || word == "esok":
+		return today.AddDate(0, 0, 1), j + 1, true
+	case word == "day" && p.is(j+1, "after") && p.is(j+2, "tomorrow"):
+		return today.AddDate(0, 0, 2), j + 3, true
+	case word == "lusa":
+		return today.AddDate(0, 0, 2), j + 1, true
+	case word == "next" && p.is(j+1, "week"):
+		return today.AddDate(0, 0, 7), j + 2, true
+	case word == "minggu" && p.is(j+1, "depan"):
+		return today.AddDate(0, 0, 7), j + 2, true
+	case word == "weekend" || (word == "this" && p.is(j+1, "weekend")) || (word == "akhir" && p.is(j+1, "pekan")):
+		next := j + 1
+		if word != "weekend" {
+			next = j + 2
+		}
+		return nextWeekday(today, time.Saturday, false), next, true
+	case word == "in" || word == "dalam":
+		if n, unit, ok := p.readCount(j + 1); ok {
+			return today.AddDate(0, 0, n*unit), j + 3, true
+		}
+	case numberPattern.MatchString(word):
+		if n, unit, ok := p.readCount(j); ok && p.is(j+2, "lagi") {
+			return today.AddDate(0, 0, n*unit), j + 3, true
+		}
+	}
+
+	if (word == "next" || word == "this") && p.free(j+1) {
+		if weekday, ok := weekdayWords[p.lower[j+1]]; ok {
+			return nextWeekday(today, weekday, word == "next"), j + 2, true
+		}
+	}
+	if weekday, ok := weekdayWords[word]; ok {
+		if p.is(j+1, "depan") {
+			return nextWeekday(today, weekday, true), j + 2, true
+		}
+		if p.is(j+1, "ini") {
+			return nextWeekday(today, weekday, false), j + 2, true
+		}
+		return nextWeekday(today, weekday, false), j + 1, true
+	}
+
+	return p.readCalendarDate(j)
+}
+
+// readCount reads "3 days", "2 weeks", "3 hari" or "2 minggu" at j and returns
+// the count and the unit in days.
+func (p *quickAddParser) readCount(j int) (int, int, bool) {
+	if !p.free(j) || !p.free(j+1) || !numberPattern.MatchString(p.lower[j]) {
+		return 0, 0, false
+	}
+
+	n, _ := strconv.Atoi(p.lower[j])
+	switch p.lower[j+1] {
+	case "day", "days", "hari":
+		return n, 1, true
+	case "week", "weeks", "minggu":
+		return n, 7, true
+	}
+
+	return 0, 0, false
+}
+
+// readCalendarDate reads "2026-10-21", "21/10", "21/10/2026", "21 Oct" or "Oct 21".
+// A date without a year that has already passed this year means next year.
+func (p *quickAddParser) readCalendarDate(j int) (time.Time, int, bool) {
+	loc := p.now.Location()
+	word := p.lower[j]
+
+	if t, err := time.ParseInLocation("2006-01-02", word, loc); err == nil {
+		return t, j + 1, true
+	}
+
+	day, month, year, next := 0, time.Month(0), 0, j+1
+	if parts := strings.Split(word, "/"); len(parts) == 2 || len(parts) == 3 {
+		d, errDay := strconv.Atoi(parts[0])
+		m, errMonth := strconv.Atoi(parts[1])
+		if errDay != nil || errMonth != nil {
+			return time.Time{}, j, false
+		}
+		day, month = d, time.Month(m)
+		if len(parts) == 3 {
+			if y, err := strconv.Atoi(parts[2]); err == nil {
+				year = y
+			}
+		}
+	} else if numberPattern.MatchString(word) && p.free(j+1) {
+		m, ok := monthWords[p.lower[j+1]]
+		if !ok {
+			return time.Time{}, j, false
+		}
+		day, _ = strconv.Atoi(word)
+		month, next = m, j+2
+	} else if m, ok := monthWords[word]; ok && p.free(j+1) && numberPattern.MatchString(p.lower[j+1]) {
+		day, _ = strconv.Atoi(p.lower[j+1])
+		month, next = m, j+2
+	} else {
+		return time.Time{}, j, false
+	}
+
+	if year == 0 && p.free(next) && len(p.lower[next]) == 4 && numberPattern.MatchString(p.lower[next]) {
+		year, _ = strconv.Atoi(p.lower[next])
+		next++
+	}
+
+	explicitYear := year != 0
+	if !explicitYear {
+		year = p.now.Year()
+	}
+
+	if month < time.January || month > time.December || day < 1 || day > 31 {
+		return time.Time{}, j, false
+	}
+	date := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if date.Day() != day {
+		return time.Time{}, j, false
+	}
+	if !explicitYear && date.Before(p.today()) {
+		date = date.AddDate(1, 0, 0)
+	}
+
+	return date, next, true
+}
+
+// nextWeekday finds weekday on or after today. With nextWeek it is the one in
+// the following Monday-to-Sunday week instead.
+func nextWeekday(today time.Time, weekday time.Weekday, nextWeek bool) time.Time {
+	if nextWeek {
+		monday := today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7)
+		return monday.AddDate(0, 0, (int(weekday)+6)%7)
+	}
+
+	return today.AddDate(0, 0, (int(weekday)-int(today.Weekday())+7)%7)
+}
+
+func (p *quickAddParser) partOfDay() {
+	for i := range p.words {
+		hour, ok := partOfDayWords[p.lower[i]]
+		if !ok || !p.free(i) {
+			continue
+		}
+
+		if p.defaultStart == nil {
+			start := time.Duration(hour) * time.Hour
+			p.defaultStart = &start
+		}
+		p.used[i] = true
+		if p.is(i-1, "the") && p.is(i-2, "in") {
+			p.consume(i-2, i)
+		}
+	}
+}
+
+func (p *quickAddParser) durations() {
+	for i := 0; i+1 < len(p.words); i++ {
+		if !p.is(i, durationPrefixes...) || !p.free(i+1) {
+			continue
+		}
+
+		m := durationPattern.FindStringSubmatch(p.lower[i+1])
+		if m == nil {
+			continue
+		}
+
+		unit, next := m[2], i+2
+		if unit == "" && p.free(next) {
+			unit, next = p.lower[next], next+1
+		}
+
+		amount, err := strconv.ParseFloat(strings.Replace(m[1], ",", ".", 1), 64)
+		if err != nil {
+			continue
+		}
+
+		switch unit {
+		case "h", "hr", "hrs", "hour", "hours", "jam":
+			p.duration = time.Duration(amount * float64(time.Hour))
+		case "m", "min", "mins", "minute", "minutes", "menit":
+			p.duration = time.Duration(amount * float64(time.Minute))
+		default:
+			continue
+		}
+
+		p.consume(i, next)
+		return
+	}
+}
+
+// phrase takes the words after the first free trigger up to the next stop word,
+// hashtag or already understood word.
+func (p *quickAddParser) phrase(triggers []string) string {
+	for i := range p.words {
+		if !p.is(i, triggers...) {
+			continue
+		}
+
+		j := i + 1
+		for p.free(j) && !p.is(j, phraseStops...) && !strings.HasPrefix(p.lower[j], "#") {
+			j++
+		}
+		if j == i+1 {
+			continue
+		}
+
+		words := make([]string, 0, j-i-1)
+		for k := i + 1; k < j; k++ {
+			words = append(words, p.words[k])
+		}
+		p.consume(i, j)
+
+		return strings.TrimRight(strings.Join(words, " "), ",.!?;")
+	}
+
+	return ""
+}
+
+// title joins the words nothing else claimed, dropping stray prepositions at either end.
+func (p *quickAddParser) title() string {
+	words := make([]string, 0, len(p.words))
+	for i, word := range p.words {
+		if !p.used[i] {
+			words = append(words, strings.TrimRight(word, ",;"))
+		}
+	}
+
+	isFiller := func(word string) bool {
+		for _, filler := range titleFillers {
+			if strings.EqualFold(word, filler) {
+				return true
+			}
+		}
+		return false
+	}
+	for len(words) > 0 && isFiller(words[len(words)-1]) {
+		words = words[:len(words)-1]
+	}
+	for len(words) > 0 && isFiller(words[0]) {
+		words = words[1:]
+	}
+
+	return strings.Join(words, " ")
+}
+
+func (p *quickAddParser) schedule(result *QuickAdd) {
+	start := p.start
+	if start == nil {
+		start = p.defaultStart
+	}
+
+	if start == nil {
+		date := p.today()
+		if p.date != nil {
+			date = *p.date
+		}
+		result.IsAllDay = true
+		result.Start = date
+		result.End = date.AddDate(0, 0, 1)
+		return
+	}
+
+	at := func(date time.Time, offset time.Duration) time.Time {
+		return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location()).Add(offset)
+	}
+
+	date := p.today()
+	if p.date != nil {
+		date = *p.date
+	} else if at(date, *start).Before(p.now) {
+		date = date.AddDate(0, 0, 1)
+	}
+
+	result.Start = at(date, *start)
+	switch {
+	case p.end != nil && p.start != nil:
+		result.End = at(date, *p.end)
+	case p.duration > 0:
+		result.End = result.Start.Add(p.duration)
+	default:
+		result.End = result.Start.Add(time.Hour)
+	}
+}
+
+// splitNames splits "Alice, Bob and Carol" or "Budi dan Ani" into names.
+func splitNames(phrase string) []string {
+	if phrase == "" {
+		return nil
+	}
+
+	phrase = strings.NewReplacer(" and ", ",", " dan ", ",", " & ", ",", " AND ", ",").Replace(phrase)
+	names := make([]string, 0)
+	for _, name := range strings.Split(phrase, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	// Monday morning.
+	now := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		year := 2026
+		if month < time.October {
+			year = 2027
+		}
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		text     string
+		title    string
+		start    time.Time
+		end      time.Time
+		allDay   bool
+		location string
+		category string
+		names    []string
+	}{
+		{
+			text:     "Study with Alice tomorrow 3-5pm at Room 101 #Study",
+			title:    "Study",
+			start:    at(time.October, 20, 15, 0),
+			end:      at(time.October, 20, 17, 0),
+			location: "Room 101",
+			category: "Study",
+			names:    []string{"Alice"},
+		},
+		{
+			text:     "Rapat dengan Budi besok jam 3 sore di Lab AI",
+			title:    "Rapat",
+			start:    at(time.October, 20, 15, 0),
+			end:      at(time.October, 20, 16, 0),
+			location: "Lab AI",
+			names:    []string{"Budi"},
+		},
+		{
+			text:   "Kuis lusa",
+			title:  "Kuis",
+			start:  at(time.October, 21, 0, 0),
+			end:    at(time.October, 22, 0, 0),
+			allDay: true,
+		},
+		{
+			text:   "Kumpul tugas minggu depan",
+			title:  "Kumpul tugas",
+			start:  at(time.October, 26, 0, 0),
+			end:    at(time.October, 27, 0, 0),
+			allDay: true,
+		},
+		{
+			text:  "Review next fri 2pm",
+			title: "Review",
+			start: at(time.October, 30, 14, 0),
+			end:   at(time.October, 30, 15, 0),
+		},
+		{
+			text:   "Quiz 21/10",
+			title:  "Quiz",
+			start:  at(time.October, 21, 0, 0),
+			end:    at(time.October, 22, 0, 0),
+			allDay: true,
+		},
+		{
+			// 5 March has already passed this year.
+			text:   "Trip 5/3",
+			title:  "Trip",
+			start:  at(time.March, 5, 0, 0),
+			end:    at(time.March, 6, 0, 0),
+			allDay: true,
+		},
+		{
+			// 9am has already passed today.
+			text:  "Standup 9am",
+			title: "Standup",
+			start: at(time.October, 20, 9, 0),
+			end:   at(time.October, 20, 10, 0),
+		},
+		{
+			// "at Room 101" is a place, not a time.
+			text:     "Meeting at Room 101",
+			title:    "Meeting",
+			start:    at(time.October, 19, 0, 0),
+			end:      at(time.October, 20, 0, 0),
+			allDay:   true,
+			location: "Room 101",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := ParseQuickAdd(tt.text, now)
+
+			if got.Title != tt.title {
+				t.Errorf("title: expected %q, got %q", tt.title, got.Title)
+			}
+			if !got.Start.Equal(tt.start) || !got.End.Equal(tt.end) {
+				t.Errorf("expected %v to %v, got %v to %v", tt.start, tt.end, got.Start, got.End)
+			}
+			if got.IsAllDay != tt.allDay {
+				t.Errorf("all-day: expected %v, got %v", tt.allDay, got.IsAllDay)
+			}
+			if got.Location != tt.location {
+				t.Errorf("location: expected %q, got %q", tt.location, got.Location)
+			}
+			if got.Category != tt.category {
+				t.Errorf("category: expected %q, got %q", tt.category, got.Category)
+			}
+			if strings.Join(got.Names, ",") != strings.Join(tt.names, ",") {
+				t.Errorf("names: expected %v, got %v", tt.names, got.Names)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+)
+
+// SearchWords splits text into lowercase words of letters and digits.
+func SearchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isWordRune(r)
+	})
+}
+
+// SearchTerms is SearchWords without duplicates, in order of first appearance.
+func SearchTerms(text string) []string {
+	words := SearchWords(text)
+
+	seen := make(map[string]bool, len(words))
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+
+	return terms
+}
+
+// MatchesTerm reports whether a word matches a search term. Terms match word
+// prefixes, so "pres" finds "presentation".
+func MatchesTerm(word string, term string) bool {
+	return strings.HasPrefix(word, term)
+}
+
+// Highlight finds the words in text that match any of terms. When text is
+// longer than maxRunes the snippet is cut to maxRunes around the first match.
+// The second result is false when nothing matches.
+func Highlight(field string, text string, terms []string, maxRunes int) (entities.SearchHighlight, bool) {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) {
+		// Lowercasing changed the length; fall back to matching case-sensitively
+		// rather than reporting shifted offsets.
+		lower = runes
+	}
+
+	ranges := make([]entities.TextRange, 0)
+	for start := 0; start < len(lower); {
+		if !isWordRune(lower[start]) {
+			start++
+			continue
+		}
+
+		end := start
+		for end < len(lower) && isWordRune(lower[end]) {
+			end++
+		}
+
+		word := string(lower[start:end])
+		for _, term := range terms {
+			if MatchesTerm(word, term) {
+				ranges = append(ranges, entities.TextRange{Start: start, End: start + len([]rune(term))})
+				break
+			}
+		}
+		start = end
+	}
+
+	if len(ranges) == 0 {
+		return entities.SearchHighlight{}, false
+	}
+
+	offset, prefix, suffix := 0, "", ""
+	if maxRunes > 0 && len(runes) > maxRunes {
+		offset = ranges[0].Start - maxRunes/4
+		if offset < 0 {
+			offset = 0
+		}
+		if offset+maxRunes > len(runes) {
+			offset = len(runes) - maxRunes
+		}
+		if offset > 0 {
+			prefix = "…"
+		}
+		if offset+maxRunes < len(runes) {
+			suffix = "…"
+		}
+		runes = runes[offset : offset+maxRunes]
+	}
+
+	shift := len([]rune(prefix)) - offset
+	kept := make([]entities.TextRange, 0, len(ranges))
+	for _, r := range ranges {
+		if r.Start < offset || r.End > offset+len(runes) {
+			continue
+		}
+		kept = append(kept, entities.TextRange{Start: r.Start + shift, End: r.End + shift})
+	}
+
+	return entities.SearchHighlight{
+		Field:   field,
+		Snippet: prefix + string(runes) + suffix,
+		Ranges:  kept,
+	}, true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+)
+
+func TestHighlightRanges(t *testing.T) {
+	highlight, ok := Highlight("title", "Final presentation prep", []string{"pres", "prep"}, 0)
+	if !ok {
+		t.Fatal("expected a match")
+	}
+
+	want := []entities.TextRange{{Start: 6, End: 10}, {Start: 19, End: 23}}
+	if len(highlight.Ranges) != len(want) {
+		t.Fatalf("expected %v, got %v", want, highlight.Ranges)
+	}
+	for i := range want {
+		if highlight.Ranges[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, highlight.Ranges)
+		}
+	}
+	if highlight.Field != "title" || highlight.Snippet != "Final presentation prep" {
+		t.Fatalf("unexpected highlight %#v", highlight)
+	}
+}
+
+func TestHighlightCountsRunes(t *testing.T) {
+	// "é" is two bytes but one rune, so "rapat" starts at rune 5.
+	highlight, ok := Highlight("location", "Café Rapat", []string{"rapat"}, 0)
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if len(highlight.Ranges) != 1 || highlight.Ranges[0] != (entities.TextRange{Start: 5, End: 10}) {
+		t.Fatalf("expected rune range 5-10, got %v", highlight.Ranges)
+	}
+}
+
+func TestHighlightSnippetOffsets(t *testing.T) {
+	text := strings.Repeat("filler ", 40) + "deadline " + strings.Repeat("filler ", 40)
+	highlight, ok := Highlight("description", text, []string{"dead"}, 60)
+	if !ok {
+		t.Fatal("expected a match")
+	}
+
+	snippet := []rune(highlight.Snippet)
+	if !strings.HasPrefix(highlight.Snippet, "…") || !strings.HasSuffix(highlight.Snippet, "…") {
+		t.Fatalf("expected an ellipsis on both sides, got %q", highlight.Snippet)
+	}
+	if len(highlight.Ranges) != 1 {
+		t.Fatalf("expected one range, got %v", highlight.Ranges)
+	}
+	r := highlight.Ranges[0]
+	if got := string(snippet[r.Start:r.End]); got != "dead" {
+		t.Fatalf("expected the range to cover %q in the snippet, got %q", "dead", got)
+	}
+}
+
+func TestHighlightWithoutMatch(t *testing.T) {
+	if _, ok := Highlight("title", "Group meeting", []string{"lab"}, 0); ok {
+		t.Fatal("expected no match")
+	}
+}
@@ -0,0 +1,119 @@
+package migrations
+
+import (
+	_ "embed"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// academicCalendarSeed is bundled into the binary. Holidays that follow the
+// lunar calendar are left out; their dates are only fixed by the government
+// each year, so Admins add them once announced.
+//
+//go:embed data/academic_calendar.json
+var academicCalendarSeed []byte
+
+type AcademicCalendarMigration interface {
+	MigrateAcademicCalendar()
+	SeedAcademicCalendar()
+}
+
+type academicCalendarMigration struct {
+	db *gorm.DB
+}
+
+func NewAcademicCalendarMigration() AcademicCalendarMigration {
+	return &academicCalendarMigration{
+		db: database.GetDB(),
+	}
+}
+
+func (c *academicCalendarMigration) MigrateAcademicCalendar() {
+	c.db.AutoMigrate(&entities.AcademicTerm{}, &entities.AcademicExclusion{})
+}
+
+type academicDateRange struct {
+	Name      string `json:"name"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+}
+
+// SeedAcademicCalendar loads the bundled calendar into an empty table only, so
+// changes Admins make are kept across restarts.
+func (c *academicCalendarMigration) SeedAcademicCalendar() {
+	var count int64
+	if err := c.db.Model(&entities.AcademicTerm{}).Count(&count).Error; err != nil {
+		log.Fatalf("Error Seeder: %s", err)
+	}
+	if count > 0 {
+		return
+	}
+
+	var seed struct {
+		Terms []struct {
+			academicDateRange
+			Breaks []academicDateRange `json:"breaks"`
+		} `json:"terms"`
+		Holidays []academicDateRange `json:"holidays"`
+	}
+	if err := json.Unmarshal(academicCalendarSeed, &seed); err != nil {
+		log.Fatalf("Error Seeder: %s", err)
+	}
+
+	err := c.db.Transaction(func(tx *gorm.DB) error {
+		for _, term := range seed.Terms {
+			model := entities.AcademicTerm{
+				Id:        uuid.New(),
+				Name:      term.Name,
+				StartDate: seedDate(term.StartDate),
+				EndDate:   seedDate(term.EndDate),
+			}
+			if err := tx.Create(&model).Error; err != nil {
+				return err
+			}
+
+			for _, termBreak := range term.Breaks {
+				if err := tx.Create(seedExclusion(termBreak, &model.Id, entities.AcademicExclusionBreak)).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, holiday := range seed.Holidays {
+			if err := tx.Create(seedExclusion(holiday, nil, entities.AcademicExclusionHoliday)).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Error Seeder: %s", err)
+	}
+}
+
+func seedExclusion(dates academicDateRange, termId *uuid.UUID, kind string) *entities.AcademicExclusion {
+	return &entities.AcademicExclusion{
+		Id:        uuid.New(),
+		TermId:    termId,
+		Name:      dates.Name,
+		Kind:      kind,
+		StartDate: seedDate(dates.StartDate),
+		EndDate:   seedDate(dates.EndDate),
+	}
+}
+
+func seedDate(value string) time.Time {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		log.Fatalf("Error Seeder: %s", err)
+	}
+
+	return date
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type AttachmentMigration interface {
+	MigrateAttachment()
+}
+
+type attachmentMigration struct {
+	db *gorm.DB
+}
+
+func NewAttachmentMigration() AttachmentMigration {
+	return &attachmentMigration{
+		db: database.GetDB(),
+	}
+}
+
+func (c *attachmentMigration) MigrateAttachment() {
+	c.db.AutoMigrate(&entities.Attachment{})
+}
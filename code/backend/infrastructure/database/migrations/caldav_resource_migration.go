@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type CalDAVResourceMigration interface {
+	MigrateCalDAVResource()
+}
+
+type calDAVResourceMigration struct {
+	db *gorm.DB
+}
+
+func NewCalDAVResourceMigration() CalDAVResourceMigration {
+	return &calDAVResourceMigration{
+		db: database.GetDB(),
+	}
+}
+
+func (c *calDAVResourceMigration) MigrateCalDAVResource() {
+	c.db.Migrator().DropTable(&entities.CalDAVResource{})
+	c.db.AutoMigrate(&entities.CalDAVResource{})
+}
@@ -0,0 +1,96 @@
+package migrations
+
+import (
+	"log"
+	"strings"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CategoryMigration interface {
+	MigrateCategory()
+	SeedCategory()
+	BackfillScheduleCategories()
+}
+
+type categoryMigration struct {
+	db *gorm.DB
+}
+
+func NewCategoryMigration() CategoryMigration {
+	return &categoryMigration{
+		db: database.GetDB(),
+	}
+}
+
+func (c *categoryMigration) MigrateCategory() {
+	c.db.Migrator().DropTable(&entities.Category{})
+	c.db.AutoMigrate(&entities.Category{})
+}
+
+func (c *categoryMigration) SeedCategory() {
+	var users []entities.User
+	if err := c.db.Find(&users).Error; err != nil {
+		log.Fatalf("Error Seeder: %s", err)
+	}
+
+	for _, user := range users {
+		for _, category := range entities.DefaultCategories {
+			category.Id = uuid.New()
+			category.UserId = user.Id
+
+			result := c.db.Create(&category)
+
+			if result.Error != nil {
+				log.Fatalf("Error Seeder: %s", result.Error)
+			}
+		}
+	}
+}
+
+// BackfillScheduleCategories turns free-form category strings into category
+// references, creating a category for any name a user does not have yet.
+func (c *categoryMigration) BackfillScheduleCategories() {
+	var schedules []entities.Schedule
+	if err := c.db.Where("category_id IS NULL AND category <> ''").Find(&schedules).Error; err != nil {
+		log.Fatalf("Error Backfill: %s", err)
+	}
+
+	known := map[string]entities.Category{}
+	var categories []entities.Category
+	if err := c.db.Find(&categories).Error; err != nil {
+		log.Fatalf("Error Backfill: %s", err)
+	}
+	for _, category := range categories {
+		known[category.UserId.String()+"|"+strings.ToLower(category.Name)] = category
+	}
+
+	for _, schedule := range schedules {
+		key := schedule.UserId.String() + "|" + strings.ToLower(strings.TrimSpace(schedule.Category))
+
+		category, ok := known[key]
+		if !ok {
+			category = entities.Category{
+				Id:     uuid.New(),
+				UserId: schedule.UserId,
+				Name:   strings.TrimSpace(schedule.Category),
+				Color:  "#6b7280",
+			}
+			if err := c.db.Create(&category).Error; err != nil {
+				log.Fatalf("Error Backfill: %s", err)
+			}
+			known[key] = category
+		}
+
+		result := c.db.Model(&entities.Schedule{}).
+			Where("id = ?", schedule.Id).
+			Updates(map[string]interface{}{"category_id": category.Id, "category": category.Name})
+
+		if result.Error != nil {
+			log.Fatalf("Error Backfill: %s", result.Error)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type CommentMigration interface {
+	MigrateComment()
+}
+
+type commentMigration struct {
+	db *gorm.DB
+}
+
+func NewCommentMigration() CommentMigration {
+	return &commentMigration{
+		db: database.GetDB(),
+	}
+}
+
+func (c *commentMigration) MigrateComment() {
+	c.db.AutoMigrate(&entities.Comment{}, &entities.CommentThreadLock{})
+}
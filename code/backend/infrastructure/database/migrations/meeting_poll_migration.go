@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type MeetingPollMigration interface {
+	MigrateMeetingPoll()
+}
+
+type meetingPollMigration struct {
+	db *gorm.DB
+}
+
+func NewMeetingPollMigration() MeetingPollMigration {
+	return &meetingPollMigration{
+		db: database.GetDB(),
+	}
+}
+
+func (c *meetingPollMigration) MigrateMeetingPoll() {
+	c.db.AutoMigrate(&entities.MeetingPoll{}, &entities.MeetingPollOption{}, &entities.MeetingPollVote{})
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type NotificationMigration interface {
+	MigrateNotification()
+}
+
+type notificationMigration struct {
+	db *gorm.DB
+}
+
+func NewNotificationMigration() NotificationMigration {
+	return &notificationMigration{
+		db: database.GetDB(),
+	}
+}
+
+func (c *notificationMigration) MigrateNotification() {
+	c.db.AutoMigrate(&entities.Notification{})
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type PersonalTokenMigration interface {
+	MigratePersonalToken()
+}
+
+type personalTokenMigration struct {
+	db *gorm.DB
+}
+
+func NewPersonalTokenMigration() PersonalTokenMigration {
+	return &personalTokenMigration{
+		db: database.GetDB(),
+	}
+}
+
+func (c *personalTokenMigration) MigratePersonalToken() {
+	c.db.Migrator().DropTable(&entities.PersonalToken{})
+	c.db.AutoMigrate(&entities.PersonalToken{})
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type ReminderMigration interface {
+	MigrateReminder()
+}
+
+type reminderMigration struct {
+	db *gorm.DB
+}
+
+func NewReminderMigration() ReminderMigration {
+	return &reminderMigration{
+		db: database.GetDB(),
+	}
+}
+
+// MigrateReminder keeps existing rows: the delivery log is what stops a restart
+// from sending the same reminder twice.
+func (c *reminderMigration) MigrateReminder() {
+	c.db.AutoMigrate(&entities.Reminder{}, &entities.ReminderDelivery{})
+}
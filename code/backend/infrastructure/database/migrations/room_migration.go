@@ -0,0 +1,112 @@
+package migrations
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RoomMigration interface {
+	MigrateRoom()
+	SeedRoom()
+	BackfillScheduleRooms()
+}
+
+type roomMigration struct {
+	db *gorm.DB
+}
+
+func NewRoomMigration() RoomMigration {
+	return &roomMigration{
+		db: database.GetDB(),
+	}
+}
+
+func (c *roomMigration) MigrateRoom() {
+	c.db.Migrator().DropTable(&entities.Room{})
+	c.db.AutoMigrate(&entities.Room{})
+}
+
+func (c *roomMigration) SeedRoom() {
+	seeds := make([]entities.Room, 0)
+
+	for floor := 1; floor <= 3; floor++ {
+		for number := 1; number <= 5; number++ {
+			seeds = append(seeds, entities.Room{
+				Name:     fmt.Sprintf("Room %d%02d", floor, number),
+				Building: "Main Building",
+				Capacity: 40,
+				Features: []string{"projector", "whiteboard"},
+			})
+		}
+	}
+	seeds = append(seeds, entities.Room{
+		Name:     "Room 803",
+		Building: "Main Building",
+		Capacity: 80,
+		Features: []string{"projector", "whiteboard", "microphone"},
+	})
+
+	labs := map[string][]string{
+		"AI Lab":        {"computers", "gpu"},
+		"Animation Lab": {"computers", "drawing tablets"},
+		"Art Studio":    {"easels", "sink"},
+		"Bio Lab":       {"fume hood", "microscopes"},
+		"Computer Lab":  {"computers"},
+		"Data Lab":      {"computers"},
+		"Diplomacy Lab": {"projector", "microphone"},
+		"Eco Lab":       {"microscopes"},
+		"Finance Lab":   {"computers", "market terminals"},
+		"Game Lab":      {"computers", "consoles"},
+		"Law Lab":       {"projector", "moot court"},
+		"Marketing Lab": {"computers", "projector"},
+		"Med Lab":       {"microscopes", "fume hood"},
+		"Media Lab":     {"cameras", "green screen"},
+		"Quantum Lab":   {"computers"},
+		"Robotics Lab":  {"workbenches", "3d printers"},
+		"Security Lab":  {"computers", "isolated network"},
+		"Sound Lab":     {"soundproofing", "mixing desk"},
+		"VR Lab":        {"vr headsets", "computers"},
+	}
+	for name, features := range labs {
+		seeds = append(seeds, entities.Room{
+			Name:     name,
+			Building: "Lab Building",
+			Capacity: 30,
+			Features: features,
+		})
+	}
+
+	for _, seed := range seeds {
+		seed.Id = uuid.New()
+
+		result := c.db.Create(&seed)
+
+		if result.Error != nil {
+			log.Fatalf("Error Seeder: %s", result.Error)
+		}
+	}
+}
+
+// BackfillScheduleRooms links schedules whose location is exactly a room name to that room.
+// Existing overlaps are kept as they are; only new bookings are checked.
+func (c *roomMigration) BackfillScheduleRooms() {
+	var rooms []entities.Room
+	if err := c.db.Find(&rooms).Error; err != nil {
+		log.Fatalf("Error Backfill: %s", err)
+	}
+
+	for _, room := range rooms {
+		result := c.db.Model(&entities.Schedule{}).
+			Where("room_id IS NULL AND location = ?", room.Name).
+			Update("room_id", room.Id)
+
+		if result.Error != nil {
+			log.Fatalf("Error Backfill: %s", result.Error)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type ScheduleProposalMigration interface {
+	MigrateScheduleProposal()
+}
+
+type scheduleProposalMigration struct {
+	db *gorm.DB
+}
+
+func NewScheduleProposalMigration() ScheduleProposalMigration {
+	return &scheduleProposalMigration{
+		db: database.GetDB(),
+	}
+}
+
+func (c *scheduleProposalMigration) MigrateScheduleProposal() {
+	c.db.AutoMigrate(&entities.ScheduleProposal{})
+}
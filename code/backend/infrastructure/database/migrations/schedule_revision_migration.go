@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type ScheduleRevisionMigration interface {
+	MigrateScheduleRevision()
+}
+
+type scheduleRevisionMigration struct {
+	db *gorm.DB
+}
+
+func NewScheduleRevisionMigration() ScheduleRevisionMigration {
+	return &scheduleRevisionMigration{
+		db: database.GetDB(),
+	}
+}
+
+func (c *scheduleRevisionMigration) MigrateScheduleRevision() {
+	c.db.AutoMigrate(&entities.ScheduleRevision{})
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"log"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+const scheduleSearchIndex = "idx_schedules_search"
+
+type ScheduleSearchMigration interface {
+	MigrateScheduleSearch()
+}
+
+type scheduleSearchMigration struct {
+	db *gorm.DB
+}
+
+func NewScheduleSearchMigration() ScheduleSearchMigration {
+	return &scheduleSearchMigration{
+		db: database.GetDB(),
+	}
+}
+
+// MigrateScheduleSearch adds the FULLTEXT index used by schedule search. It
+// must run after MigrateSchedule, which recreates the table. Other databases
+// search with the in-process index and need nothing here.
+func (c *scheduleSearchMigration) MigrateScheduleSearch() {
+	if c.db.Dialector.Name() != "mysql" || c.db.Migrator().HasIndex(&entities.Schedule{}, scheduleSearchIndex) {
+		return
+	}
+
+	err := c.db.Exec("CREATE FULLTEXT INDEX " + scheduleSearchIndex + " ON schedules (title, description, location)").Error
+	if err != nil {
+		log.Fatalf("Error Migration: %s", err)
+	}
+}
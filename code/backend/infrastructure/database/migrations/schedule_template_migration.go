@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type ScheduleTemplateMigration interface {
+	MigrateScheduleTemplate()
+}
+
+type scheduleTemplateMigration struct {
+	db *gorm.DB
+}
+
+func NewScheduleTemplateMigration() ScheduleTemplateMigration {
+	return &scheduleTemplateMigration{
+		db: database.GetDB(),
+	}
+}
+
+func (c *scheduleTemplateMigration) MigrateScheduleTemplate() {
+	c.db.AutoMigrate(&entities.ScheduleTemplate{})
+}
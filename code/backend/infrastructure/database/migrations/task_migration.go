@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type TaskMigration interface {
+	MigrateTask()
+}
+
+type taskMigration struct {
+	db *gorm.DB
+}
+
+func NewTaskMigration() TaskMigration {
+	return &taskMigration{
+		db: database.GetDB(),
+	}
+}
+
+func (c *taskMigration) MigrateTask() {
+	c.db.AutoMigrate(&entities.Task{})
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AcademicCalendarRepository interface {
+	CreateNewTerm(model entities.AcademicTerm) error
+	FindTerm(id uuid.UUID) (entities.AcademicTerm, error)
+	FindTermByDate(date time.Time) (entities.AcademicTerm, error)
+	GetAllTerms() ([]entities.AcademicTerm, error)
+	GetOverlappingTerms(start time.Time, end time.Time) ([]entities.AcademicTerm, error)
+	UpdateTerm(model entities.AcademicTerm) error
+	DeleteTerm(id uuid.UUID) error
+	CreateNewExclusion(model entities.AcademicExclusion) error
+	FindExclusion(id uuid.UUID) (entities.AcademicExclusion, error)
+	GetExclusionsBetween(start time.Time, end time.Time) ([]entities.AcademicExclusion, error)
+	UpdateExclusion(model entities.AcademicExclusion) error
+	DeleteExclusion(id uuid.UUID) error
+}
+
+type academicCalendarRepository struct {
+	db *gorm.DB
+}
+
+func NewAcademicCalendarRepository() AcademicCalendarRepository {
+	return &academicCalendarRepository{db: database.GetDB()}
+}
+
+func (r *academicCalendarRepository) CreateNewTerm(model entities.AcademicTerm) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *academicCalendarRepository) FindTerm(id uuid.UUID) (entities.AcademicTerm, error) {
+	var entity entities.AcademicTerm
+
+	err := r.db.Where("id = ?", id).First(&entity).Error
+	return entity, err
+}
+
+func (r *academicCalendarRepository) FindTermByDate(date time.Time) (entities.AcademicTerm, error) {
+	var entity entities.AcademicTerm
+
+	day := date.Format("2006-01-02")
+	err := r.db.Where("start_date <= ? AND end_date >= ?", day, day).First(&entity).Error
+	return entity, err
+}
+
+func (r *academicCalendarRepository) GetAllTerms() ([]entities.AcademicTerm, error) {
+	var entities []entities.AcademicTerm
+
+	err := r.db.Order("start_date").Find(&entities).Error
+	return entities, err
+}
+
+// GetOverlappingTerms returns the terms sharing a day with [start, end], both inclusive.
+func (r *academicCalendarRepository) GetOverlappingTerms(start time.Time, end time.Time) ([]entities.AcademicTerm, error) {
+	var entities []entities.AcademicTerm
+
+	err := r.db.Where("start_date <= ? AND end_date >= ?", end.Format("2006-01-02"), start.Format("2006-01-02")).
+		Order("start_date").Find(&entities).Error
+	return entities, err
+}
+
+func (r *academicCalendarRepository) UpdateTerm(model entities.AcademicTerm) error {
+	return r.db.Save(&model).Error
+}
+
+// DeleteTerm also deletes the breaks that belong to the term.
+func (r *academicCalendarRepository) DeleteTerm(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("term_id = ?", id).Delete(&entities.AcademicExclusion{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&entities.AcademicTerm{}).Error
+	})
+}
+
+func (r *academicCalendarRepository) CreateNewExclusion(model entities.AcademicExclusion) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *academicCalendarRepository) FindExclusion(id uuid.UUID) (entities.AcademicExclusion, error) {
+	var entity entities.AcademicExclusion
+
+	err := r.db.Where("id = ?", id).First(&entity).Error
+	return entity, err
+}
+
+// GetExclusionsBetween returns the exclusions sharing a day with [start, end], both inclusive.
+func (r *academicCalendarRepository) GetExclusionsBetween(start time.Time, end time.Time) ([]entities.AcademicExclusion, error) {
+	var entities []entities.AcademicExclusion
+
+	err := r.db.Where("start_date <= ? AND end_date >= ?", end.Format("2006-01-02"), start.Format("2006-01-02")).
+		Order("start_date").Find(&entities).Error
+	return entities, err
+}
+
+func (r *academicCalendarRepository) UpdateExclusion(model entities.AcademicExclusion) error {
+	return r.db.Save(&model).Error
+}
+
+func (r *academicCalendarRepository) DeleteExclusion(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&entities.AcademicExclusion{}).Error
+}
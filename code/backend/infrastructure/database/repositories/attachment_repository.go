@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+	CreateNewAttachment(model entities.Attachment) error
+	FindAttachment(id uuid.UUID) (entities.Attachment, error)
+	GetAttachmentsBySchedule(scheduleId uuid.UUID) ([]entities.Attachment, error)
+	DeleteAttachment(id uuid.UUID) error
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository() AttachmentRepository {
+	return &attachmentRepository{db: database.GetDB()}
+}
+
+func (r *attachmentRepository) CreateNewAttachment(model entities.Attachment) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *attachmentRepository) FindAttachment(id uuid.UUID) (entities.Attachment, error) {
+	var entity entities.Attachment
+
+	err := r.db.Where("id = ?", id).First(&entity).Error
+	return entity, err
+}
+
+func (r *attachmentRepository) GetAttachmentsBySchedule(scheduleId uuid.UUID) ([]entities.Attachment, error) {
+	var entities []entities.Attachment
+
+	err := r.db.Where("schedule_id = ?", scheduleId).Order("created_at").Find(&entities).Error
+	return entities, err
+}
+
+func (r *attachmentRepository) DeleteAttachment(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&entities.Attachment{}).Error
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CalDAVResourceRepository interface {
+	CreateNewCalDAVResource(model entities.CalDAVResource) error
+	FindCalDAVResourceByHref(userId uuid.UUID, href string) (entities.CalDAVResource, error)
+	GetCalDAVResourcesByUser(userId uuid.UUID) ([]entities.CalDAVResource, error)
+	DeleteCalDAVResourceBySchedule(scheduleId uuid.UUID) error
+}
+
+type calDAVResourceRepository struct {
+	db *gorm.DB
+}
+
+func NewCalDAVResourceRepository() CalDAVResourceRepository {
+	return &calDAVResourceRepository{db: database.GetDB()}
+}
+
+func (r *calDAVResourceRepository) CreateNewCalDAVResource(model entities.CalDAVResource) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *calDAVResourceRepository) FindCalDAVResourceByHref(userId uuid.UUID, href string) (entities.CalDAVResource, error) {
+	var entity entities.CalDAVResource
+
+	err := r.db.Where("user_id = ? AND href = ?", userId, href).First(&entity).Error
+	return entity, err
+}
+
+func (r *calDAVResourceRepository) GetCalDAVResourcesByUser(userId uuid.UUID) ([]entities.CalDAVResource, error) {
+	var entities []entities.CalDAVResource
+
+	err := r.db.Where("user_id = ?", userId).Find(&entities).Error
+	return entities, err
+}
+
+func (r *calDAVResourceRepository) DeleteCalDAVResourceBySchedule(scheduleId uuid.UUID) error {
+	return r.db.Where("schedule_id = ?", scheduleId).Delete(&entities.CalDAVResource{}).Error
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CategoryRepository interface {
+	CreateNewCategory(model entities.Category) error
+	BatchCreateNewCategory(models []entities.Category) error
+	FindCategory(id uuid.UUID) (entities.Category, error)
+	FindCategoryByName(userId uuid.UUID, name string) (entities.Category, error)
+	GetCategoriesByUser(userId uuid.UUID) ([]entities.Category, error)
+	UpdateCategory(model entities.Category) error
+	DeleteCategory(id uuid.UUID) error
+}
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository() CategoryRepository {
+	return &categoryRepository{db: database.GetDB()}
+}
+
+func (r *categoryRepository) CreateNewCategory(model entities.Category) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *categoryRepository) BatchCreateNewCategory(models []entities.Category) error {
+	return r.db.Create(&models).Error
+}
+
+func (r *categoryRepository) FindCategory(id uuid.UUID) (entities.Category, error) {
+	var entity entities.Category
+
+	err := r.db.First(&entity, id).Error
+	return entity, err
+}
+
+// FindCategoryByName matches case-insensitively so "study" and "Study" are one category.
+func (r *categoryRepository) FindCategoryByName(userId uuid.UUID, name string) (entities.Category, error) {
+	var entity entities.Category
+
+	err := r.db.Where("user_id = ? AND LOWER(name) = LOWER(?)", userId, name).First(&entity).Error
+	return entity, err
+}
+
+func (r *categoryRepository) GetCategoriesByUser(userId uuid.UUID) ([]entities.Category, error) {
+	var entities []entities.Category
+
+	err := r.db.Where("user_id = ?", userId).Order("name").Find(&entities).Error
+	return entities, err
+}
+
+// UpdateCategory also renames the denormalized category string on the user's schedules.
+func (r *categoryRepository) UpdateCategory(model entities.Category) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&model).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&entities.Schedule{}).
+			Where("category_id = ?", model.Id).
+			Update("category", model.Name).Error
+	})
+}
+
+// DeleteCategory leaves the category's schedules uncategorized.
+func (r *categoryRepository) DeleteCategory(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&entities.Schedule{}).
+			Where("category_id = ?", id).
+			Updates(map[string]interface{}{"category_id": nil, "category": ""}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&entities.Category{}, id).Error
+	})
+}
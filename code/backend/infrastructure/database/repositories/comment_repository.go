@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CommentRepository interface {
+	CreateNewComment(model entities.Comment) error
+	FindComment(id uuid.UUID) (entities.Comment, error)
+	GetCommentsBySchedule(scheduleId uuid.UUID, offset int, limit int) ([]entities.Comment, int64, error)
+	UpdateComment(model entities.Comment) error
+	DeleteComment(id uuid.UUID) error
+	IsThreadLocked(scheduleId uuid.UUID) (bool, error)
+	LockThread(model entities.CommentThreadLock) error
+	UnlockThread(scheduleId uuid.UUID) error
+}
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository() CommentRepository {
+	return &commentRepository{db: database.GetDB()}
+}
+
+func (r *commentRepository) CreateNewComment(model entities.Comment) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *commentRepository) FindComment(id uuid.UUID) (entities.Comment, error) {
+	var entity entities.Comment
+
+	err := r.db.Where("id = ?", id).First(&entity).Error
+	return entity, err
+}
+
+// GetCommentsBySchedule returns one page of comments, oldest first, and the total count.
+func (r *commentRepository) GetCommentsBySchedule(scheduleId uuid.UUID, offset int, limit int) ([]entities.Comment, int64, error) {
+	var entities []entities.Comment
+	var total int64
+
+	if err := r.db.Model(&entities).Where("schedule_id = ?", scheduleId).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.Where("schedule_id = ?", scheduleId).Order("created_at, id").Offset(offset).Limit(limit).Find(&entities).Error
+	return entities, total, err
+}
+
+func (r *commentRepository) UpdateComment(model entities.Comment) error {
+	return r.db.Save(&model).Error
+}
+
+func (r *commentRepository) DeleteComment(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&entities.Comment{}).Error
+}
+
+func (r *commentRepository) IsThreadLocked(scheduleId uuid.UUID) (bool, error) {
+	var count int64
+
+	err := r.db.Model(&entities.CommentThreadLock{}).Where("schedule_id = ?", scheduleId).Count(&count).Error
+	return count > 0, err
+}
+
+// LockThread is a no-op when the thread is already locked.
+func (r *commentRepository) LockThread(model entities.CommentThreadLock) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model).Error
+}
+
+func (r *commentRepository) UnlockThread(scheduleId uuid.UUID) error {
+	return r.db.Where("schedule_id = ?", scheduleId).Delete(&entities.CommentThreadLock{}).Error
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrPollNotOpen is returned when a poll was finalized or cancelled by another
+// request first.
+var ErrPollNotOpen = errors.New("meeting poll is no longer open")
+
+type MeetingPollRepository interface {
+	CreateNewMeetingPoll(model entities.MeetingPoll, options []entities.MeetingPollOption) error
+	FindMeetingPoll(id uuid.UUID) (entities.MeetingPoll, error)
+	GetMeetingPollsByUser(userID uuid.UUID) ([]entities.MeetingPoll, error)
+	GetMeetingPollOptions(pollID uuid.UUID) ([]entities.MeetingPollOption, error)
+	GetMeetingPollVotes(pollID uuid.UUID) ([]entities.MeetingPollVote, error)
+	ReplaceVotes(pollID uuid.UUID, userID uuid.UUID, votes []entities.MeetingPollVote) error
+	FinalizeMeetingPoll(model entities.MeetingPoll, schedule entities.Schedule, participants []entities.ScheduleParticipant) error
+	CancelMeetingPoll(id uuid.UUID) error
+}
+
+type meetingPollRepository struct {
+	db *gorm.DB
+}
+
+func NewMeetingPollRepository() MeetingPollRepository {
+	return &meetingPollRepository{db: database.GetDB()}
+}
+
+func (r *meetingPollRepository) CreateNewMeetingPoll(model entities.MeetingPoll, options []entities.MeetingPollOption) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&options).Error
+	})
+}
+
+func (r *meetingPollRepository) FindMeetingPoll(id uuid.UUID) (entities.MeetingPoll, error) {
+	var entity entities.MeetingPoll
+
+	err := r.db.Where("id = ?", id).First(&entity).Error
+	return entity, err
+}
+
+// GetMeetingPollsByUser returns the polls the user organizes or is invited to,
+// newest first. Invitees are stored as a JSON list, so they are matched in Go.
+func (r *meetingPollRepository) GetMeetingPollsByUser(userID uuid.UUID) ([]entities.MeetingPoll, error) {
+	var polls []entities.MeetingPoll
+
+	if err := r.db.Order("created_at DESC").Find(&polls).Error; err != nil {
+		return nil, err
+	}
+
+	matched := make([]entities.MeetingPoll, 0)
+	for _, poll := range polls {
+		if poll.UserId == userID {
+			matched = append(matched, poll)
+			continue
+		}
+		for _, inviteeID := range poll.InviteeIds {
+			if inviteeID == userID {
+				matched = append(matched, poll)
+				break
+			}
+		}
+	}
+
+	return matched, nil
+}
+
+func (r *meetingPollRepository) GetMeetingPollOptions(pollID uuid.UUID) ([]entities.MeetingPollOption, error) {
+	var entities []entities.MeetingPollOption
+
+	err := r.db.Where("poll_id = ?", pollID).Order("start_time").Find(&entities).Error
+	return entities, err
+}
+
+func (r *meetingPollRepository) GetMeetingPollVotes(pollID uuid.UUID) ([]entities.MeetingPollVote, error) {
+	var entities []entities.MeetingPollVote
+
+	err := r.db.Where("poll_id = ?", pollID).Find(&entities).Error
+	return entities, err
+}
+
+// ReplaceVotes swaps the user's answers for the options in votes and leaves
+// their answers for other options alone.
+func (r *meetingPollRepository) ReplaceVotes(pollID uuid.UUID, userID uuid.UUID, votes []entities.MeetingPollVote) error {
+	optionIDs := make([]uuid.UUID, 0, len(votes))
+	for _, vote := range votes {
+		optionIDs = append(optionIDs, vote.OptionId)
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("poll_id = ? AND user_id = ? AND option_id IN ?", pollID, userID, optionIDs).
+			Delete(&entities.MeetingPollVote{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&votes).Error
+	})
+}
+
+// FinalizeMeetingPoll creates the schedule with its participants and closes the
+// poll in one transaction, failing with ErrPollNotOpen if the poll was closed
+// concurrently.
+func (r *meetingPollRepository) FinalizeMeetingPoll(model entities.MeetingPoll, schedule entities.Schedule, participants []entities.ScheduleParticipant) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.MeetingPoll{}).
+			Where("id = ? AND status = ?", model.Id, entities.MeetingPollOpen).
+			Updates(map[string]interface{}{
+				"status":          entities.MeetingPollFinalized,
+				"final_option_id": model.FinalOptionId,
+				"schedule_id":     schedule.Id,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPollNotOpen
+		}
+
+		if err := lockRoomBookings(tx, schedule); err != nil {
+			return err
+		}
+		if err := tx.Create(&schedule).Error; err != nil {
+			return err
+		}
+		if len(participants) == 0 {
+			return nil
+		}
+
+		return tx.Create(&participants).Error
+	})
+}
+
+func (r *meetingPollRepository) CancelMeetingPoll(id uuid.UUID) error {
+	result := r.db.Model(&entities.MeetingPoll{}).
+		Where("id = ? AND status = ?", id, entities.MeetingPollOpen).
+		Update("status", entities.MeetingPollCancelled)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPollNotOpen
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	CreateNewNotification(model entities.Notification) error
+	GetNotificationsByUser(userId uuid.UUID) ([]entities.Notification, error)
+	MarkNotificationRead(id uuid.UUID, userId uuid.UUID, readAt time.Time) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository() NotificationRepository {
+	return &notificationRepository{db: database.GetDB()}
+}
+
+// CreateNewNotification ignores a notification whose Id already exists, which
+// makes redelivering the same reminder harmless.
+func (r *notificationRepository) CreateNewNotification(model entities.Notification) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model).Error
+}
+
+func (r *notificationRepository) GetNotificationsByUser(userId uuid.UUID) ([]entities.Notification, error) {
+	var entities []entities.Notification
+
+	err := r.db.Where("user_id = ?", userId).Order("created_at desc").Find(&entities).Error
+	return entities, err
+}
+
+func (r *notificationRepository) MarkNotificationRead(id uuid.UUID, userId uuid.UUID, readAt time.Time) error {
+	return r.db.Model(&entities.Notification{}).
+		Where("id = ? AND user_id = ?", id, userId).
+		Update("read_at", readAt).Error
+}
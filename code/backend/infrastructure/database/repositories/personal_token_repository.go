@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PersonalTokenRepository interface {
+	CreateNewPersonalToken(model entities.PersonalToken) error
+	FindPersonalTokenByHash(hash string) (entities.PersonalToken, error)
+	GetPersonalTokensByUser(userId uuid.UUID) ([]entities.PersonalToken, error)
+	TouchPersonalToken(id uuid.UUID, usedAt time.Time) error
+	DeletePersonalToken(id uuid.UUID, userId uuid.UUID) error
+}
+
+type personalTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPersonalTokenRepository() PersonalTokenRepository {
+	return &personalTokenRepository{db: database.GetDB()}
+}
+
+func (r *personalTokenRepository) CreateNewPersonalToken(model entities.PersonalToken) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *personalTokenRepository) FindPersonalTokenByHash(hash string) (entities.PersonalToken, error) {
+	var entity entities.PersonalToken
+
+	err := r.db.Where("token_hash = ?", hash).First(&entity).Error
+	return entity, err
+}
+
+func (r *personalTokenRepository) GetPersonalTokensByUser(userId uuid.UUID) ([]entities.PersonalToken, error) {
+	var entities []entities.PersonalToken
+
+	err := r.db.Where("user_id = ?", userId).Order("created_at desc").Find(&entities).Error
+	return entities, err
+}
+
+func (r *personalTokenRepository) TouchPersonalToken(id uuid.UUID, usedAt time.Time) error {
+	return r.db.Model(&entities.PersonalToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt).Error
+}
+
+func (r *personalTokenRepository) DeletePersonalToken(id uuid.UUID, userId uuid.UUID) error {
+	result := r.db.Where("id = ? AND user_id = ?", id, userId).Delete(&entities.PersonalToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReminderRepository interface {
+	GetScheduleReminders(userId uuid.UUID, scheduleId uuid.UUID) ([]entities.Reminder, error)
+	GetDefaultReminders(userId uuid.UUID) ([]entities.Reminder, error)
+	ReplaceScheduleReminders(userId uuid.UUID, scheduleId uuid.UUID, models []entities.Reminder) error
+	ReplaceDefaultReminders(userId uuid.UUID, models []entities.Reminder) error
+	ClaimDelivery(model entities.ReminderDelivery) (bool, error)
+	ReclaimDelivery(model entities.ReminderDelivery, claimedAt time.Time) (bool, error)
+	UpdateDelivery(model entities.ReminderDelivery) error
+	GetRetryableDeliveries(staleBefore time.Time, maxAttempts int) ([]entities.ReminderDelivery, error)
+}
+
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository() ReminderRepository {
+	return &reminderRepository{db: database.GetDB()}
+}
+
+func (r *reminderRepository) GetScheduleReminders(userId uuid.UUID, scheduleId uuid.UUID) ([]entities.Reminder, error) {
+	var entities []entities.Reminder
+
+	err := r.db.Where("user_id = ? AND schedule_id = ?", userId, scheduleId).Order("offset_minutes").Find(&entities).Error
+	return entities, err
+}
+
+func (r *reminderRepository) GetDefaultReminders(userId uuid.UUID) ([]entities.Reminder, error) {
+	var entities []entities.Reminder
+
+	err := r.db.Where("user_id = ? AND schedule_id IS NULL", userId).Order("offset_minutes").Find(&entities).Error
+	return entities, err
+}
+
+func (r *reminderRepository) ReplaceScheduleReminders(userId uuid.UUID, scheduleId uuid.UUID, models []entities.Reminder) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND schedule_id = ?", userId, scheduleId).Delete(&entities.Reminder{}).Error; err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		return tx.Create(&models).Error
+	})
+}
+
+func (r *reminderRepository) ReplaceDefaultReminders(userId uuid.UUID, models []entities.Reminder) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND schedule_id IS NULL", userId).Delete(&entities.Reminder{}).Error; err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			return nil
+		}
+		return tx.Create(&models).Error
+	})
+}
+
+// ClaimDelivery inserts the delivery and reports whether this call created it.
+func (r *reminderRepository) ClaimDelivery(model entities.ReminderDelivery) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// ReclaimDelivery moves a failed or stale delivery back to Sending and counts the
+// attempt. The update only matches the status and attempts the caller read, so of
+// several workers racing for the same row exactly one gets true.
+func (r *reminderRepository) ReclaimDelivery(model entities.ReminderDelivery, claimedAt time.Time) (bool, error) {
+	result := r.db.Model(&entities.ReminderDelivery{}).
+		Where("id = ? AND status = ? AND attempts = ?", model.Id, model.Status, model.Attempts).
+		Updates(map[string]interface{}{
+			"status":     "Sending",
+			"attempts":   gorm.Expr("attempts + 1"),
+			"claimed_at": claimedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// UpdateDelivery records the outcome of a claimed delivery. It only applies while
+// the row is still the caller's claim, so a worker that was presumed dead cannot
+// overwrite the result of the one that took over.
+func (r *reminderRepository) UpdateDelivery(model entities.ReminderDelivery) error {
+	return r.db.Model(&entities.ReminderDelivery{}).
+		Where("id = ? AND status = ? AND attempts = ?", model.Id, "Sending", model.Attempts).
+		Updates(map[string]interface{}{
+			"status":     model.Status,
+			"sent_at":    model.SentAt,
+			"last_error": model.LastError,
+		}).Error
+}
+
+// GetRetryableDeliveries returns failed deliveries and ones left in Sending by a
+// worker that stopped before finishing.
+func (r *reminderRepository) GetRetryableDeliveries(staleBefore time.Time, maxAttempts int) ([]entities.ReminderDelivery, error) {
+	var entities []entities.ReminderDelivery
+
+	err := r.db.Where("attempts < ? AND (status = ? OR (status = ? AND claimed_at < ?))", maxAttempts, "Failed", "Sending", staleBefore).
+		Find(&entities).Error
+	return entities, err
+}
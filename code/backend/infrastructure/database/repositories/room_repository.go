@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrRoomUnavailable = errors.New("room is already booked at that time")
+
+type RoomRepository interface {
+	CreateNewRoom(model entities.Room) error
+	FindRoom(id uuid.UUID) (entities.Room, error)
+	FindRoomByName(name string) (entities.Room, error)
+	GetAllRooms() ([]entities.Room, error)
+	UpdateRoom(model entities.Room) error
+	DeleteRoom(id uuid.UUID) error
+	GetAvailableRooms(start time.Time, end time.Time, minCapacity int, building string) ([]entities.Room, error)
+}
+
+type roomRepository struct {
+	db *gorm.DB
+}
+
+func NewRoomRepository() RoomRepository {
+	return &roomRepository{db: database.GetDB()}
+}
+
+func (r *roomRepository) CreateNewRoom(model entities.Room) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *roomRepository) FindRoom(id uuid.UUID) (entities.Room, error) {
+	var entity entities.Room
+
+	err := r.db.Where("id = ?", id).First(&entity).Error
+	return entity, err
+}
+
+func (r *roomRepository) FindRoomByName(name string) (entities.Room, error) {
+	var entity entities.Room
+
+	err := r.db.Where("name = ?", name).First(&entity).Error
+	return entity, err
+}
+
+func (r *roomRepository) GetAllRooms() ([]entities.Room, error) {
+	var entities []entities.Room
+
+	err := r.db.Order("building, name").Find(&entities).Error
+	return entities, err
+}
+
+// UpdateRoom also renames the denormalized location string on the room's schedules.
+func (r *roomRepository) UpdateRoom(model entities.Room) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&model).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&entities.Schedule{}).
+			Where("room_id = ?", model.Id).
+			Update("location", model.Name).Error
+	})
+}
+
+// DeleteRoom releases the room's bookings but keeps their location text.
+func (r *roomRepository) DeleteRoom(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&entities.Schedule{}).
+			Where("room_id = ?", id).
+			Update("room_id", nil).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&entities.Room{}).Error
+	})
+}
+
+// GetAvailableRooms returns rooms with no booking overlapping [start, end).
+func (r *roomRepository) GetAvailableRooms(start time.Time, end time.Time, minCapacity int, building string) ([]entities.Room, error) {
+	var entities []entities.Room
+
+	booked := r.db.Table("schedules").
+		Select("room_id").
+		Where("room_id IS NOT NULL AND deleted_at IS NULL AND start_time < ? AND end_time > ?", end, start)
+
+	query := r.db.Where("capacity >= ? AND id NOT IN (?)", minCapacity, booked)
+	if building != "" {
+		query = query.Where("building = ?", building)
+	}
+
+	err := query.Order("capacity, name").Find(&entities).Error
+	return entities, err
+}
+
+// lockRoomBookings locks the rooms booked by models and fails with ErrRoomUnavailable
+// if another schedule already holds one of them. It must run inside a transaction so
+// concurrent bookings of the same room are serialized on the room row.
+func lockRoomBookings(tx *gorm.DB, models ...entities.Schedule) error {
+	for i, model := range models {
+		if model.RoomId == nil {
+			continue
+		}
+
+		var room entities.Room
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", *model.RoomId).
+			First(&room).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&entities.Schedule{}).
+			Where("room_id = ? AND id <> ? AND start_time < ? AND end_time > ?", *model.RoomId, model.Id, model.EndTime, model.StartTime).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrRoomUnavailable
+		}
+
+		for _, other := range models[:i] {
+			if other.RoomId != nil && *other.RoomId == *model.RoomId &&
+				other.StartTime.Before(model.EndTime) && other.EndTime.After(model.StartTime) {
+				return ErrRoomUnavailable
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrProposalNotPending is returned when a proposal was accepted, declined or
+// superseded by another request first.
+var ErrProposalNotPending = errors.New("proposal is no longer pending")
+
+type ScheduleProposalRepository interface {
+	CreateNewScheduleProposal(model entities.ScheduleProposal) error
+	FindScheduleProposal(id uuid.UUID) (entities.ScheduleProposal, error)
+	GetScheduleProposalsBySchedule(scheduleID uuid.UUID) ([]entities.ScheduleProposal, error)
+	AcceptScheduleProposal(model entities.ScheduleProposal, schedule entities.Schedule) error
+	DeclineScheduleProposal(id uuid.UUID) error
+}
+
+type scheduleProposalRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleProposalRepository() ScheduleProposalRepository {
+	return &scheduleProposalRepository{db: database.GetDB()}
+}
+
+// CreateNewScheduleProposal supersedes the user's earlier pending proposal for
+// the same schedule.
+func (r *scheduleProposalRepository) CreateNewScheduleProposal(model entities.ScheduleProposal) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entities.ScheduleProposal{}).
+			Where("schedule_id = ? AND user_id = ? AND status = ?", model.ScheduleId, model.UserId, entities.ProposalPending).
+			Update("status", entities.ProposalSuperseded).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&model).Error
+	})
+}
+
+func (r *scheduleProposalRepository) FindScheduleProposal(id uuid.UUID) (entities.ScheduleProposal, error) {
+	var entity entities.ScheduleProposal
+
+	err := r.db.Where("id = ?", id).First(&entity).Error
+	return entity, err
+}
+
+func (r *scheduleProposalRepository) GetScheduleProposalsBySchedule(scheduleID uuid.UUID) ([]entities.ScheduleProposal, error) {
+	var entities []entities.ScheduleProposal
+
+	err := r.db.Where("schedule_id = ?", scheduleID).Order("created_at DESC").Find(&entities).Error
+	return entities, err
+}
+
+// AcceptScheduleProposal moves the schedule to the proposed time and, in the
+// same transaction, accepts the proposal for its author, puts every other
+// participant back to Pending and supersedes the other pending proposals.
+// schedule.Version must be the version the caller read.
+func (r *scheduleProposalRepository) AcceptScheduleProposal(model entities.ScheduleProposal, schedule entities.Schedule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.ScheduleProposal{}).
+			Where("id = ? AND status = ?", model.Id, entities.ProposalPending).
+			Update("status", entities.ProposalAccepted)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrProposalNotPending
+		}
+
+		if err := lockRoomBookings(tx, schedule); err != nil {
+			return err
+		}
+		if err := updateVersioned(tx, &schedule, &schedule.Version, "UserId", "DeletedAt"); err != nil {
+			return err
+		}
+
+		err := tx.Model(&entities.ScheduleParticipant{}).
+			Where("schedule_id = ? AND user_id <> ?", schedule.Id, model.UserId).
+			Update("status", "Pending").Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Model(&entities.ScheduleParticipant{}).
+			Where("schedule_id = ? AND user_id = ?", schedule.Id, model.UserId).
+			Update("status", "Accepted").Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&entities.ScheduleProposal{}).
+			Where("schedule_id = ? AND status = ?", schedule.Id, entities.ProposalPending).
+			Update("status", entities.ProposalSuperseded).Error
+	})
+}
+
+func (r *scheduleProposalRepository) DeclineScheduleProposal(id uuid.UUID) error {
+	result := r.db.Model(&entities.ScheduleProposal{}).
+		Where("id = ? AND status = ?", id, entities.ProposalPending).
+		Update("status", entities.ProposalDeclined)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrProposalNotPending
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
 	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
 	"github.com/google/uuid"
@@ -16,10 +18,22 @@ type ScheduleRepository interface {
 	UpdateSchedule(model entities.Schedule) error
 	DeleteSchedule(id uuid.UUID) error
 	AcceptSchedule(id uuid.UUID) error
+	FindScheduleParticipant(id uuid.UUID) (entities.ScheduleParticipant, error)
+	SetParticipantRole(scheduleID uuid.UUID, userID uuid.UUID, role string) (bool, error)
 	RejectSchedule(id uuid.UUID) error
 	GetAllScheduleRequestsByUser(userID uuid.UUID) ([]entities.ScheduleParticipant, error)
 	GetAllScheduleRequestsBySchedule(scheduleID uuid.UUID) ([]entities.ScheduleParticipant, error)
 	GetAllAcceptedSchedulesBySchedule(scheduleID uuid.UUID) ([]entities.ScheduleParticipant, error)
+	GetBusySchedulesByUser(userID uuid.UUID, start time.Time, end time.Time) ([]entities.Schedule, error)
+	GetParticipantsByScheduleOwner(ownerID uuid.UUID, start time.Time, end time.Time) ([]entities.ScheduleParticipant, error)
+	CreateScheduleWithParticipants(model entities.Schedule, participants []entities.ScheduleParticipant) error
+	GetSchedulesStartingBetween(start time.Time, end time.Time) ([]entities.Schedule, error)
+	FindDeletedSchedule(id uuid.UUID) (entities.Schedule, error)
+	GetDeletedSchedulesByUser(userID uuid.UUID) ([]entities.Schedule, error)
+	GetDeletedScheduleIdsBefore(before time.Time) ([]uuid.UUID, error)
+	RestoreSchedule(model entities.Schedule) error
+	PurgeSchedule(id uuid.UUID) error
+	WithTransaction(fn func(repo ScheduleRepository) error) error
 }
 
 type scheduleRepository struct {
@@ -30,12 +44,32 @@ func NewScheduleRepository() ScheduleRepository {
 	return &scheduleRepository{db: database.GetDB()}
 }
 
+// WithTransaction runs fn against a repository bound to a single transaction,
+// which commits if fn returns nil and rolls back otherwise.
+func (r *scheduleRepository) WithTransaction(fn func(repo ScheduleRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&scheduleRepository{db: tx})
+	})
+}
+
 func (r *scheduleRepository) BatchCreateNewSchedule(models []entities.Schedule) error {
-	return r.db.Create(&models).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockRoomBookings(tx, models...); err != nil {
+			return err
+		}
+
+		return tx.Create(&models).Error
+	})
 }
 
 func (r *scheduleRepository) CreateNewSchedule(model entities.Schedule) error {
-	return r.db.Create(&model).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockRoomBookings(tx, model); err != nil {
+			return err
+		}
+
+		return tx.Create(&model).Error
+	})
 }
 
 func (r *scheduleRepository) BatchAddParticipantsToSchedule(participants []entities.ScheduleParticipant) error {
@@ -57,9 +91,17 @@ func (r *scheduleRepository) GetAllSchedules(userID string) ([]entities.Schedule
 }
 
 func (r *scheduleRepository) UpdateSchedule(model entities.Schedule) error {
-	return r.db.Save(&model).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockRoomBookings(tx, model); err != nil {
+			return err
+		}
+
+		return updateVersioned(tx, &model, &model.Version, "UserId", "DeletedAt")
+	})
 }
 
+// DeleteSchedule moves the schedule to the trash. Its participants, comments and
+// attachments stay in place until the schedule is purged.
 func (r *scheduleRepository) DeleteSchedule(id uuid.UUID) error {
 	return r.db.Delete(&entities.Schedule{}, id).Error
 }
@@ -70,6 +112,34 @@ func (r *scheduleRepository) AcceptSchedule(id uuid.UUID) error {
 		Update("status", "Accepted").Error
 }
 
+func (r *scheduleRepository) FindScheduleParticipant(id uuid.UUID) (entities.ScheduleParticipant, error) {
+	var entity entities.ScheduleParticipant
+
+	err := r.db.Where("id = ?", id).First(&entity).Error
+	return entity, err
+}
+
+// SetParticipantRole reports whether userID was invited to the schedule at all.
+// The existence check is a separate count because MySQL reports changed rows, not
+// matched ones, so re-setting the current role would look like a missing row.
+func (r *scheduleRepository) SetParticipantRole(scheduleID uuid.UUID, userID uuid.UUID, role string) (bool, error) {
+	var count int64
+
+	if err := r.db.Model(&entities.ScheduleParticipant{}).
+		Where("schedule_id = ? AND user_id = ?", scheduleID, userID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count == 0 {
+		return false, nil
+	}
+
+	err := r.db.Model(&entities.ScheduleParticipant{}).
+		Where("schedule_id = ? AND user_id = ?", scheduleID, userID).
+		Update("role", role).Error
+	return true, err
+}
+
 func (r *scheduleRepository) RejectSchedule(id uuid.UUID) error {
 	return r.db.Model(&entities.ScheduleParticipant{}).
 		Where("id", id).
@@ -96,3 +166,118 @@ func (r *scheduleRepository) GetAllAcceptedSchedulesBySchedule(scheduleID uuid.U
 	err := r.db.Where("schedule_id = ? AND status = ?", scheduleID, "Accepted").Find(&participants).Error
 	return participants, err
 }
+
+// GetBusySchedulesByUser returns owned and accepted schedules overlapping [start, end).
+func (r *scheduleRepository) GetBusySchedulesByUser(userID uuid.UUID, start time.Time, end time.Time) ([]entities.Schedule, error) {
+	var entities []entities.Schedule
+
+	accepted := r.db.Table("schedule_participants").
+		Select("schedule_id").
+		Where("user_id = ? AND status = ?", userID, "Accepted")
+
+	err := r.db.Where("(user_id = ? OR id IN (?)) AND start_time < ? AND end_time > ?", userID, accepted, end, start).
+		Order("start_time").
+		Find(&entities).Error
+	return entities, err
+}
+
+// GetParticipantsByScheduleOwner returns the invitations on schedules owned by ownerID
+// that start within [start, end).
+func (r *scheduleRepository) GetParticipantsByScheduleOwner(ownerID uuid.UUID, start time.Time, end time.Time) ([]entities.ScheduleParticipant, error) {
+	var participants []entities.ScheduleParticipant
+
+	err := r.db.Joins("JOIN schedules ON schedules.id = schedule_participants.schedule_id").
+		Where("schedules.user_id = ? AND schedules.start_time >= ? AND schedules.start_time < ? AND schedules.deleted_at IS NULL", ownerID, start, end).
+		Find(&participants).Error
+	return participants, err
+}
+
+func (r *scheduleRepository) CreateScheduleWithParticipants(model entities.Schedule, participants []entities.ScheduleParticipant) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockRoomBookings(tx, model); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&model).Error; err != nil {
+			return err
+		}
+
+		if len(participants) == 0 {
+			return nil
+		}
+
+		return tx.Create(&participants).Error
+	})
+}
+
+func (r *scheduleRepository) GetSchedulesStartingBetween(start time.Time, end time.Time) ([]entities.Schedule, error) {
+	var entities []entities.Schedule
+
+	err := r.db.Where("start_time > ? AND start_time <= ?", start, end).Order("start_time").Find(&entities).Error
+	return entities, err
+}
+
+func (r *scheduleRepository) FindDeletedSchedule(id uuid.UUID) (entities.Schedule, error) {
+	var entity entities.Schedule
+
+	err := r.db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&entity).Error
+	return entity, err
+}
+
+func (r *scheduleRepository) GetDeletedSchedulesByUser(userID uuid.UUID) ([]entities.Schedule, error) {
+	var entities []entities.Schedule
+
+	err := r.db.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).Order("deleted_at desc").Find(&entities).Error
+	return entities, err
+}
+
+func (r *scheduleRepository) GetDeletedScheduleIdsBefore(before time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+
+	err := r.db.Unscoped().Model(&entities.Schedule{}).Where("deleted_at < ?", before).Pluck("id", &ids).Error
+	return ids, err
+}
+
+// RestoreSchedule takes a schedule out of the trash, failing if its room was booked in the meantime.
+func (r *scheduleRepository) RestoreSchedule(model entities.Schedule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockRoomBookings(tx, model); err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&entities.Schedule{}).Where("id = ?", model.Id).Update("deleted_at", nil).Error
+	})
+}
+
+// PurgeSchedule permanently deletes a schedule and everything attached to it.
+// Tasks and meeting polls that point at it are kept and unlinked. Attachment
+// blobs are not touched; callers remove them first.
+func (r *scheduleRepository) PurgeSchedule(id uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		dependents := []interface{}{
+			&entities.ScheduleParticipant{},
+			&entities.Attachment{},
+			&entities.Comment{},
+			&entities.CommentThreadLock{},
+			&entities.Reminder{},
+			&entities.ReminderDelivery{},
+			&entities.CalDAVResource{},
+			&entities.ScheduleRevision{},
+			&entities.ScheduleProposal{},
+		}
+		for _, dependent := range dependents {
+			if err := tx.Where("schedule_id = ?", id).Delete(dependent).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&entities.Task{}).Where("schedule_id = ?", id).Update("schedule_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entities.MeetingPoll{}).Where("schedule_id = ?", id).Update("schedule_id", nil).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Where("id = ?", id).Delete(&entities.Schedule{}).Error
+	})
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ScheduleRevisionRepository interface {
+	CreateNewScheduleRevision(model entities.ScheduleRevision) error
+	FindScheduleRevision(id uuid.UUID) (entities.ScheduleRevision, error)
+	GetRevisionsBySchedule(scheduleId uuid.UUID) ([]entities.ScheduleRevision, error)
+}
+
+type scheduleRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleRevisionRepository() ScheduleRevisionRepository {
+	return &scheduleRevisionRepository{db: database.GetDB()}
+}
+
+func (r *scheduleRevisionRepository) CreateNewScheduleRevision(model entities.ScheduleRevision) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *scheduleRevisionRepository) FindScheduleRevision(id uuid.UUID) (entities.ScheduleRevision, error) {
+	var entity entities.ScheduleRevision
+
+	err := r.db.Where("id = ?", id).First(&entity).Error
+	return entity, err
+}
+
+// GetRevisionsBySchedule returns the newest revision first.
+func (r *scheduleRevisionRepository) GetRevisionsBySchedule(scheduleId uuid.UUID) ([]entities.ScheduleRevision, error) {
+	var entities []entities.ScheduleRevision
+
+	err := r.db.Where("schedule_id = ?", scheduleId).Order("changed_at desc").Find(&entities).Error
+	return entities, err
+}
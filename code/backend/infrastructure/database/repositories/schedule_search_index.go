@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"math"
+	"sort"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Matches in the title count more than in the location, and those more than in
+// the description.
+var searchFieldWeights = map[string]float64{
+	"title":       3,
+	"location":    2,
+	"description": 1,
+}
+
+// indexScheduleSearchRepository builds an inverted index over the user's
+// schedules for each search. It needs nothing from the database beyond plain
+// queries, so it works on any SQL backend.
+type indexScheduleSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewIndexScheduleSearchRepository returns the in-process implementation
+// regardless of the database in use.
+func NewIndexScheduleSearchRepository(db *gorm.DB) ScheduleSearchRepository {
+	return &indexScheduleSearchRepository{db: db}
+}
+
+func (r *indexScheduleSearchRepository) SearchSchedules(userID uuid.UUID, terms []string, limit int) ([]entities.ScheduleSearchHit, error) {
+	var schedules []entities.Schedule
+	if err := visibleSchedules(r.db, userID).Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+
+	index := NewScheduleSearchIndex(schedules)
+	return index.Search(terms, limit), nil
+}
+
+// ScheduleSearchIndex maps each word to the schedules containing it, with the
+// weighted number of occurrences per schedule.
+type ScheduleSearchIndex struct {
+	schedules []entities.Schedule
+	postings  map[string]map[int]float64
+	lengths   []float64
+}
+
+func NewScheduleSearchIndex(schedules []entities.Schedule) *ScheduleSearchIndex {
+	index := &ScheduleSearchIndex{
+		schedules: schedules,
+		postings:  make(map[string]map[int]float64),
+		lengths:   make([]float64, len(schedules)),
+	}
+
+	for doc, schedule := range schedules {
+		fields := map[string]string{
+			"title":       schedule.Title,
+			"location":    schedule.Location,
+			"description": schedule.Description,
+		}
+		for field, text := range fields {
+			for _, word := range utils.SearchWords(text) {
+				if index.postings[word] == nil {
+					index.postings[word] = make(map[int]float64)
+				}
+				index.postings[word][doc] += searchFieldWeights[field]
+				index.lengths[doc]++
+			}
+		}
+	}
+
+	return index
+}
+
+// Search scores documents matching every term with BM25, treating all words a
+// term is a prefix of as that term.
+func (index *ScheduleSearchIndex) Search(terms []string, limit int) []entities.ScheduleSearchHit {
+	if len(terms) == 0 || len(index.schedules) == 0 {
+		return []entities.ScheduleSearchHit{}
+	}
+
+	const k1, b = 1.2, 0.75
+
+	averageLength := 0.0
+	for _, length := range index.lengths {
+		averageLength += length
+	}
+	averageLength /= float64(len(index.lengths))
+	if averageLength == 0 {
+		averageLength = 1
+	}
+
+	scores := make(map[int]float64)
+	for i, term := range terms {
+		frequencies := make(map[int]float64)
+		for word, postings := range index.postings {
+			if !utils.MatchesTerm(word, term) {
+				continue
+			}
+			for doc, frequency := range postings {
+				frequencies[doc] += frequency
+			}
+		}
+
+		n := float64(len(index.schedules))
+		idf := math.Log(1 + (n-float64(len(frequencies))+0.5)/(float64(len(frequencies))+0.5))
+
+		next := make(map[int]float64, len(frequencies))
+		for doc, frequency := range frequencies {
+			if _, ok := scores[doc]; !ok && i > 0 {
+				continue
+			}
+			norm := k1 * (1 - b + b*index.lengths[doc]/averageLength)
+			next[doc] = scores[doc] + idf*frequency*(k1+1)/(frequency+norm)
+		}
+		scores = next
+	}
+
+	hits := make([]entities.ScheduleSearchHit, 0, len(scores))
+	for doc, score := range scores {
+		hits = append(hits, entities.ScheduleSearchHit{Schedule: index.schedules[doc], Score: score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Schedule.StartTime.After(hits[j].Schedule.StartTime)
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits
+}
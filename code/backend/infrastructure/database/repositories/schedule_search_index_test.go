@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+)
+
+func searchTitles(hits []entities.ScheduleSearchHit) []string {
+	titles := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		titles = append(titles, hit.Schedule.Title)
+	}
+	return titles
+}
+
+func TestScheduleSearchIndexRequiresEveryTerm(t *testing.T) {
+	index := NewScheduleSearchIndex([]entities.Schedule{
+		{Title: "Database lecture", Location: "Room 203"},
+		{Title: "Database lab", Location: "AI Lab"},
+		{Title: "Networking lab", Location: "Room 205"},
+	})
+
+	hits := index.Search([]string{"database", "lab"}, 0)
+	if titles := searchTitles(hits); len(titles) != 1 || titles[0] != "Database lab" {
+		t.Fatalf("expected only %q, got %v", "Database lab", titles)
+	}
+
+	if hits := index.Search([]string{"database", "calculus"}, 0); len(hits) != 0 {
+		t.Fatalf("expected no hits when a term is missing, got %v", searchTitles(hits))
+	}
+}
+
+func TestScheduleSearchIndexMatchesPrefixes(t *testing.T) {
+	index := NewScheduleSearchIndex([]entities.Schedule{
+		{Title: "Final presentation"},
+		{Title: "Group meeting"},
+	})
+
+	hits := index.Search([]string{"pres"}, 0)
+	if titles := searchTitles(hits); len(titles) != 1 || titles[0] != "Final presentation" {
+		t.Fatalf("expected %q for prefix %q, got %v", "Final presentation", "pres", titles)
+	}
+
+	// Terms are prefixes of words, not substrings.
+	if hits := index.Search([]string{"sentation"}, 0); len(hits) != 0 {
+		t.Fatalf("expected no hits for a word suffix, got %v", searchTitles(hits))
+	}
+}
+
+func TestScheduleSearchIndexRanksFieldsByWeight(t *testing.T) {
+	// Every schedule has the same number of words so only the field differs.
+	index := NewScheduleSearchIndex([]entities.Schedule{
+		{Title: "other", Location: "place", Description: "review"},
+		{Title: "location", Location: "review", Description: "notes"},
+		{Title: "review", Location: "place", Description: "notes"},
+	})
+
+	hits := index.Search([]string{"review"}, 0)
+	titles := searchTitles(hits)
+	want := []string{"review", "location", "other"}
+	if len(titles) != len(want) {
+		t.Fatalf("expected %v, got %v", want, titles)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, titles)
+		}
+	}
+	if !(hits[0].Score > hits[1].Score && hits[1].Score > hits[2].Score) {
+		t.Fatalf("expected strictly decreasing scores, got %v, %v, %v", hits[0].Score, hits[1].Score, hits[2].Score)
+	}
+}
+
+func TestScheduleSearchIndexLimit(t *testing.T) {
+	index := NewScheduleSearchIndex([]entities.Schedule{
+		{Title: "Study session 1"},
+		{Title: "Study session 2"},
+		{Title: "Study session 3"},
+	})
+
+	if hits := index.Search([]string{"study"}, 2); len(hits) != 2 {
+		t.Fatalf("expected 2 hits with limit 2, got %d", len(hits))
+	}
+	if hits := index.Search([]string{"study"}, 0); len(hits) != 3 {
+		t.Fatalf("expected every hit without a limit, got %d", len(hits))
+	}
+}
+
+func TestScheduleSearchIndexEmpty(t *testing.T) {
+	index := NewScheduleSearchIndex([]entities.Schedule{{Title: "Study session"}})
+	if hits := index.Search(nil, 10); hits == nil || len(hits) != 0 {
+		t.Fatalf("expected an empty slice without terms, got %#v", hits)
+	}
+
+	empty := NewScheduleSearchIndex(nil)
+	if hits := empty.Search([]string{"study"}, 10); hits == nil || len(hits) != 0 {
+		t.Fatalf("expected an empty slice without schedules, got %#v", hits)
+	}
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScheduleSearchRepository finds the schedules a user owns or has accepted
+// whose title, description or location match every term, best match first.
+// Terms come from utils.SearchTerms and match word prefixes.
+type ScheduleSearchRepository interface {
+	SearchSchedules(userID uuid.UUID, terms []string, limit int) ([]entities.ScheduleSearchHit, error)
+}
+
+// NewScheduleSearchRepository uses the FULLTEXT index on MySQL and the
+// in-process index on any other database.
+func NewScheduleSearchRepository() ScheduleSearchRepository {
+	db := database.GetDB()
+	if db.Dialector.Name() == "mysql" {
+		return &fullTextScheduleSearchRepository{db: db, fallback: &indexScheduleSearchRepository{db: db}}
+	}
+
+	return &indexScheduleSearchRepository{db: db}
+}
+
+// ftMinTokenSize is InnoDB's default innodb_ft_min_token_size. Shorter words
+// are not in the FULLTEXT index.
+const ftMinTokenSize = 3
+
+type fullTextScheduleSearchRepository struct {
+	db       *gorm.DB
+	fallback ScheduleSearchRepository
+}
+
+type scoredSchedule struct {
+	entities.Schedule
+	Score float64
+}
+
+// SearchSchedules runs a boolean-mode MATCH that requires every term as a
+// prefix. Searches with a term too short for the index go to the in-process
+// index instead, since MySQL would not find them.
+func (r *fullTextScheduleSearchRepository) SearchSchedules(userID uuid.UUID, terms []string, limit int) ([]entities.ScheduleSearchHit, error) {
+	expression := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if utf8.RuneCountInString(term) < ftMinTokenSize {
+			return r.fallback.SearchSchedules(userID, terms, limit)
+		}
+		expression = append(expression, "+"+term+"*")
+	}
+	against := strings.Join(expression, " ")
+
+	var rows []scoredSchedule
+	err := visibleSchedules(r.db, userID).
+		Select("schedules.*, MATCH(title, description, location) AGAINST (? IN BOOLEAN MODE) AS score", against).
+		Where("MATCH(title, description, location) AGAINST (? IN BOOLEAN MODE)", against).
+		Order("score DESC, start_time DESC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]entities.ScheduleSearchHit, 0, len(rows))
+	for _, row := range rows {
+		hits = append(hits, entities.ScheduleSearchHit{Schedule: row.Schedule, Score: row.Score})
+	}
+
+	return hits, nil
+}
+
+// visibleSchedules scopes a query to the user's own and accepted schedules.
+func visibleSchedules(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	accepted := db.Table("schedule_participants").
+		Select("schedule_id").
+		Where("user_id = ? AND status = ?", userID, "Accepted")
+
+	return db.Model(&entities.Schedule{}).Where("user_id = ? OR id IN (?)", userID, accepted)
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ScheduleTemplateRepository interface {
+	CreateNewScheduleTemplate(model entities.ScheduleTemplate) error
+	FindScheduleTemplate(id uuid.UUID) (entities.ScheduleTemplate, error)
+	GetScheduleTemplatesByUser(userID uuid.UUID) ([]entities.ScheduleTemplate, error)
+	UpdateScheduleTemplate(model entities.ScheduleTemplate) error
+	DeleteScheduleTemplate(id uuid.UUID) error
+}
+
+type scheduleTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleTemplateRepository() ScheduleTemplateRepository {
+	return &scheduleTemplateRepository{db: database.GetDB()}
+}
+
+func (r *scheduleTemplateRepository) CreateNewScheduleTemplate(model entities.ScheduleTemplate) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *scheduleTemplateRepository) FindScheduleTemplate(id uuid.UUID) (entities.ScheduleTemplate, error) {
+	var entity entities.ScheduleTemplate
+
+	err := r.db.Where("id = ?", id).First(&entity).Error
+	return entity, err
+}
+
+func (r *scheduleTemplateRepository) GetScheduleTemplatesByUser(userID uuid.UUID) ([]entities.ScheduleTemplate, error) {
+	var entities []entities.ScheduleTemplate
+
+	err := r.db.Where("user_id = ?", userID).Order("name").Find(&entities).Error
+	return entities, err
+}
+
+func (r *scheduleTemplateRepository) UpdateScheduleTemplate(model entities.ScheduleTemplate) error {
+	return r.db.Save(&model).Error
+}
+
+func (r *scheduleTemplateRepository) DeleteScheduleTemplate(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&entities.ScheduleTemplate{}).Error
+}
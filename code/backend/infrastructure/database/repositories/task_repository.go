@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/infrastructure/database"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TaskRepository interface {
+	CreateNewTask(model entities.Task) error
+	FindTask(id uuid.UUID) (entities.Task, error)
+	GetTasks(query entities.TaskQuery) ([]entities.Task, error)
+	UpdateTask(model entities.Task) error
+	DeleteTask(id uuid.UUID) error
+}
+
+type taskRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskRepository() TaskRepository {
+	return &taskRepository{db: database.GetDB()}
+}
+
+func (r *taskRepository) CreateNewTask(model entities.Task) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *taskRepository) FindTask(id uuid.UUID) (entities.Task, error) {
+	var entity entities.Task
+
+	err := r.db.Where("id = ?", id).First(&entity).Error
+	return entity, err
+}
+
+// GetTasks returns the tasks matching query, soonest due first.
+func (r *taskRepository) GetTasks(query entities.TaskQuery) ([]entities.Task, error) {
+	var entities []entities.Task
+
+	tx := r.db.Where("user_id = ?", query.UserId)
+	if query.Completed != nil {
+		tx = tx.Where("is_completed = ?", *query.Completed)
+	}
+	if query.DueFrom != nil {
+		tx = tx.Where("due_date >= ?", *query.DueFrom)
+	}
+	if query.DueTo != nil {
+		tx = tx.Where("due_date < ?", *query.DueTo)
+	}
+	if query.Priority != "" {
+		tx = tx.Where("priority = ?", query.Priority)
+	}
+	if query.Course != "" {
+		tx = tx.Where("course = ?", query.Course)
+	}
+	if query.ScheduleId != nil {
+		tx = tx.Where("schedule_id = ?", *query.ScheduleId)
+	}
+
+	err := tx.Order("due_date").Find(&entities).Error
+	return entities, err
+}
+
+func (r *taskRepository) UpdateTask(model entities.Task) error {
+	return r.db.Save(&model).Error
+}
+
+func (r *taskRepository) DeleteTask(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&entities.Task{}).Error
+}
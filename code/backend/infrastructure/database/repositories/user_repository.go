@@ -57,7 +57,7 @@ func (r *userRepository) GetAllUsers() ([]entities.User, error) {
 }
 
 func (r *userRepository) UpdateUser(model entities.User) error {
-	return r.db.Save(&model).Error
+	return updateVersioned(r.db, &model, &model.Version)
 }
 
 func (r *userRepository) DeleteUser(email string) error {
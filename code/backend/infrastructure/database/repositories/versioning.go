@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict means the row was changed or removed since the caller read it.
+var ErrVersionConflict = errors.New("version conflict")
+
+// updateVersioned writes every column of model except the primary key and omit,
+// but only while the stored version still equals *version, and bumps *version.
+func updateVersioned(tx *gorm.DB, model interface{}, version *int, omit ...string) error {
+	expected := *version
+	*version = expected + 1
+
+	result := tx.Model(model).Where("version = ?", expected).Select("*").Omit(append(omit, "Id")...).Updates(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strings"
+)
+
+var (
+	ErrMailNotConfigured = errors.New("smtp is not configured")
+	ErrInvalidRecipient  = errors.New("invalid mail recipient")
+)
+
+type Sender interface {
+	Send(to string, subject string, body string) error
+}
+
+type smtpSender struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPSender reads SMTP_HOST, SMTP_PORT, SMTP_USER, SMTP_PASSWORD and SMTP_FROM.
+func NewSMTPSender() Sender {
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+
+	return &smtpSender{
+		host:     os.Getenv("SMTP_HOST"),
+		port:     port,
+		username: os.Getenv("SMTP_USER"),
+		password: os.Getenv("SMTP_PASSWORD"),
+		from:     os.Getenv("SMTP_FROM"),
+	}
+}
+
+func (s *smtpSender) Send(to string, subject string, body string) error {
+	if s.host == "" || s.from == "" {
+		return ErrMailNotConfigured
+	}
+
+	// Recipient and subject come from user data, so neither may carry CR/LF
+	// into the headers.
+	if strings.ContainsAny(to, "\r\n") {
+		return ErrInvalidRecipient
+	}
+	address, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRecipient, err)
+	}
+	subject = mime.QEncoding.Encode("utf-8", stripLineBreaks(subject))
+
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+
+	message := strings.Join([]string{
+		"From: " + s.from,
+		"To: " + address.String(),
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"",
+		body,
+	}, "\r\n")
+
+	if err := smtp.SendMail(s.host+":"+s.port, auth, s.from, []string{address.Address}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send mail: %v", err)
+	}
+
+	return nil
+}
+
+func stripLineBreaks(value string) string {
+	return strings.NewReplacer("\r", "", "\n", " ").Replace(value)
+}
@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AcademicCalendarHandler interface {
+	GetTerms(c *gin.Context)
+	GetTerm(c *gin.Context)
+	CreateTerm(c *gin.Context)
+	UpdateTerm(c *gin.Context)
+	DeleteTerm(c *gin.Context)
+	GetExclusions(c *gin.Context)
+	CreateExclusion(c *gin.Context)
+	UpdateExclusion(c *gin.Context)
+	DeleteExclusion(c *gin.Context)
+}
+
+type academicCalendarHandler struct {
+	service services.AcademicCalendarService
+}
+
+func NewAcademicCalendarHandler() AcademicCalendarHandler {
+	return &academicCalendarHandler{
+		service: services.NewAcademicCalendarService(),
+	}
+}
+
+// Dates are "2006-01-02" and both ends are inclusive.
+type academicTermRequest struct {
+	UserId    uuid.UUID `json:"userId"`
+	Name      string    `json:"name"`
+	StartDate string    `json:"startDate"`
+	EndDate   string    `json:"endDate"`
+}
+
+type academicExclusionRequest struct {
+	UserId    uuid.UUID  `json:"userId"`
+	TermId    *uuid.UUID `json:"termId"`
+	Name      string     `json:"name"`
+	Kind      string     `json:"kind"`
+	StartDate string     `json:"startDate"`
+	EndDate   string     `json:"endDate"`
+}
+
+func parseDateRange(startDate string, endDate string) (time.Time, time.Time, error) {
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return start, end, nil
+}
+
+func (r academicTermRequest) toTerm() (entities.AcademicTerm, error) {
+	start, end, err := parseDateRange(r.StartDate, r.EndDate)
+	if err != nil {
+		return entities.AcademicTerm{}, err
+	}
+
+	return entities.AcademicTerm{
+		Name:      r.Name,
+		StartDate: start,
+		EndDate:   end,
+	}, nil
+}
+
+func (r academicExclusionRequest) toExclusion() (entities.AcademicExclusion, error) {
+	start, end, err := parseDateRange(r.StartDate, r.EndDate)
+	if err != nil {
+		return entities.AcademicExclusion{}, err
+	}
+
+	return entities.AcademicExclusion{
+		TermId:    r.TermId,
+		Name:      r.Name,
+		Kind:      r.Kind,
+		StartDate: start,
+		EndDate:   end,
+	}, nil
+}
+
+func (h *academicCalendarHandler) GetTerms(c *gin.Context) {
+	terms, err := h.service.GetTerms()
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, terms)
+}
+
+func (h *academicCalendarHandler) GetTerm(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid term ID"})
+		return
+	}
+
+	term, err := h.service.GetTermByID(id)
+	if err != nil {
+		writeAcademicCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, term)
+}
+
+func (h *academicCalendarHandler) CreateTerm(c *gin.Context) {
+	var request academicTermRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	Term, err := request.toTerm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date"})
+		return
+	}
+
+	term, err := h.service.CreateNewTerm(request.UserId, Term)
+	if err != nil {
+		writeAcademicCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, term)
+}
+
+func (h *academicCalendarHandler) UpdateTerm(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid term ID"})
+		return
+	}
+
+	var request academicTermRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	Term, err := request.toTerm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date"})
+		return
+	}
+
+	Term.Id = id
+	if err := h.service.UpdateTerm(request.UserId, Term); err != nil {
+		writeAcademicCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Term updated"})
+}
+
+func (h *academicCalendarHandler) DeleteTerm(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid term ID"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.service.DeleteTerm(id, userID); err != nil {
+		writeAcademicCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Term deleted"})
+}
+
+func (h *academicCalendarHandler) GetExclusions(c *gin.Context) {
+	type ExclusionQueryRequest struct {
+		StartDate string `json:"startDate"`
+		EndDate   string `json:"endDate"`
+	}
+
+	var queryRequest ExclusionQueryRequest
+
+	if err := c.ShouldBindJSON(&queryRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	start, end, err := parseDateRange(queryRequest.StartDate, queryRequest.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date"})
+		return
+	}
+
+	exclusions, err := h.service.GetExclusions(start, end)
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, exclusions)
+}
+
+func (h *academicCalendarHandler) CreateExclusion(c *gin.Context) {
+	var request academicExclusionRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	Exclusion, err := request.toExclusion()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date"})
+		return
+	}
+
+	exclusion, err := h.service.CreateNewExclusion(request.UserId, Exclusion)
+	if err != nil {
+		writeAcademicCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, exclusion)
+}
+
+func (h *academicCalendarHandler) UpdateExclusion(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid exclusion ID"})
+		return
+	}
+
+	var request academicExclusionRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	Exclusion, err := request.toExclusion()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date"})
+		return
+	}
+
+	Exclusion.Id = id
+	if err := h.service.UpdateExclusion(request.UserId, Exclusion); err != nil {
+		writeAcademicCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Exclusion updated"})
+}
+
+func (h *academicCalendarHandler) DeleteExclusion(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid exclusion ID"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.service.DeleteExclusion(id, userID); err != nil {
+		writeAcademicCalendarError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Exclusion deleted"})
+}
+
+func writeAcademicCalendarError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrAcademicTermNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Term not found"})
+	case errors.Is(err, services.ErrAcademicExclusionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Exclusion not found"})
+	case errors.Is(err, services.ErrAcademicCalendarForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidAcademicCalendar):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
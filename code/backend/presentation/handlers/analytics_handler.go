@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AnalyticsHandler interface {
+	GetTimeAnalytics(c *gin.Context)
+}
+
+type analyticsHandler struct {
+	service services.AnalyticsService
+}
+
+func NewAnalyticsHandler() AnalyticsHandler {
+	return &analyticsHandler{
+		service: services.NewAnalyticsService(),
+	}
+}
+
+func (h *analyticsHandler) GetTimeAnalytics(c *gin.Context) {
+	type AnalyticsRequest struct {
+		UserId    uuid.UUID `json:"userId"`
+		StartDate string    `json:"startDate"`
+		EndDate   string    `json:"endDate"`
+		Timezone  string    `json:"timezone"`
+	}
+
+	var analyticsRequest AnalyticsRequest
+
+	if err := c.ShouldBindJSON(&analyticsRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	loc := time.UTC
+	if analyticsRequest.Timezone != "" {
+		l, err := time.LoadLocation(analyticsRequest.Timezone)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timezone"})
+			return
+		}
+		loc = l
+	}
+
+	// Schedules hold wall-clock times, so the zone only decides which date
+	// "today" is when the range is left out.
+	today := time.Now().In(loc).Format("2006-01-02")
+	if analyticsRequest.StartDate == "" {
+		analyticsRequest.StartDate = today
+	}
+	if analyticsRequest.EndDate == "" {
+		analyticsRequest.EndDate = today
+	}
+
+	startDate, err := time.Parse("2006-01-02", analyticsRequest.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start date"})
+		return
+	}
+
+	endDate, err := time.Parse("2006-01-02", analyticsRequest.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end date"})
+		return
+	}
+
+	// The end date is inclusive.
+	analytics, err := h.service.GetTimeAnalytics(entities.AnalyticsQuery{
+		UserId: analyticsRequest.UserId,
+		Start:  startDate,
+		End:    endDate.AddDate(0, 0, 1),
+	})
+	if errors.Is(err, services.ErrInvalidAnalyticsQuery) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BulkScheduleHandler interface {
+	Apply(c *gin.Context)
+}
+
+type bulkScheduleHandler struct {
+	service services.BulkScheduleService
+}
+
+func NewBulkScheduleHandler() BulkScheduleHandler {
+	return &bulkScheduleHandler{
+		service: services.NewBulkScheduleService(),
+	}
+}
+
+func (h *bulkScheduleHandler) Apply(c *gin.Context) {
+	type BulkFilter struct {
+		CategoryId *uuid.UUID `json:"categoryId"`
+		Start      string     `json:"start"`
+		End        string     `json:"end"`
+	}
+
+	type BulkRequest struct {
+		UserId       uuid.UUID   `json:"userId"`
+		ScheduleIds  []uuid.UUID `json:"scheduleIds"`
+		Filter       *BulkFilter `json:"filter"`
+		Operation    string      `json:"operation"`
+		ShiftMinutes int         `json:"shiftMinutes"`
+		Location     string      `json:"location"`
+		RoomId       *uuid.UUID  `json:"roomId"`
+		CategoryId   *uuid.UUID  `json:"categoryId"`
+		DryRun       bool        `json:"dryRun"`
+	}
+
+	var bulkRequest BulkRequest
+
+	if err := c.ShouldBindJSON(&bulkRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	request := entities.BulkScheduleRequest{
+		UserId:      bulkRequest.UserId,
+		ScheduleIds: bulkRequest.ScheduleIds,
+		Operation:   bulkRequest.Operation,
+		Shift:       time.Duration(bulkRequest.ShiftMinutes) * time.Minute,
+		Location:    bulkRequest.Location,
+		RoomId:      bulkRequest.RoomId,
+		CategoryId:  bulkRequest.CategoryId,
+		DryRun:      bulkRequest.DryRun,
+	}
+
+	if filter := bulkRequest.Filter; filter != nil {
+		start, err := time.Parse("2006-01-02T15:04:05", filter.Start)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter start"})
+			return
+		}
+
+		end, err := time.Parse("2006-01-02T15:04:05", filter.End)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid filter end"})
+			return
+		}
+
+		request.Filter = entities.BulkScheduleFilter{CategoryId: filter.CategoryId, Start: start, End: end}
+	}
+
+	result, err := h.service.Apply(request)
+	if errors.Is(err, services.ErrInvalidBulkRequest) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	// Nothing was written when an item failed, so report it as a conflict with
+	// the per-item results for the client to fix and retry.
+	if !result.Applied && !result.DryRun {
+		c.JSON(http.StatusConflict, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,553 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   CalDAV layout (RFC 4791 subset):
+
+   /caldav/                          -> discovery, points at the principal
+   /caldav/:userId/                  -> principal and calendar home
+   /caldav/:userId/calendar/         -> the user's calendar collection
+   /caldav/:userId/calendar/:name    -> one VEVENT resource per schedule
+
+   Clients authenticate with HTTP Basic (email + personal token) or a Bearer token.
+*/
+
+const (
+	calDAVPrefix         = "/caldav"
+	calDAVCalendarName   = "calendar"
+	calDAVMaxObjectBytes = 1 << 20
+
+	davNamespace       = "DAV:"
+	calDAVNamespace    = "urn:ietf:params:xml:ns:caldav"
+	calServerNamespace = "http://calendarserver.org/ns/"
+)
+
+// CalDAVMethods lists the HTTP methods routed to Serve.
+var CalDAVMethods = []string{"OPTIONS", "PROPFIND", "REPORT", "GET", "HEAD", "PUT", "DELETE"}
+
+type CalDAVHandler interface {
+	Authenticate(c *gin.Context)
+	WellKnown(c *gin.Context)
+	Serve(c *gin.Context)
+}
+
+type calDAVHandler struct {
+	service      services.CalDAVService
+	tokenService services.PersonalTokenService
+}
+
+func NewCalDAVHandler() CalDAVHandler {
+	return &calDAVHandler{
+		service:      services.NewCalDAVService(),
+		tokenService: services.NewPersonalTokenService(),
+	}
+}
+
+func (h *calDAVHandler) Authenticate(c *gin.Context) {
+	if c.Request.Method == http.MethodOptions {
+		c.Next()
+		return
+	}
+
+	token := ""
+	username := ""
+	if user, password, ok := c.Request.BasicAuth(); ok {
+		username, token = user, password
+	} else if bearer, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); found {
+		token = strings.TrimSpace(bearer)
+	}
+
+	user, err := h.tokenService.Authenticate(token)
+	if err == nil && username != "" && !strings.EqualFold(username, user.Email) && username != user.Id.String() {
+		err = services.ErrInvalidToken
+	}
+	if err != nil {
+		if !errors.Is(err, services.ErrInvalidToken) {
+			log.Println(err)
+		}
+		c.Header("WWW-Authenticate", `Basic realm="RUsman CalDAV"`)
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	c.Set("calDAVUser", user)
+	c.Next()
+}
+
+func (h *calDAVHandler) WellKnown(c *gin.Context) {
+	c.Redirect(http.StatusMovedPermanently, calDAVPrefix+"/")
+}
+
+func (h *calDAVHandler) Serve(c *gin.Context) {
+	if c.Request.Method == http.MethodOptions {
+		c.Header("DAV", "1, 3, calendar-access")
+		c.Header("Allow", strings.Join(CalDAVMethods, ", "))
+		c.Status(http.StatusOK)
+		return
+	}
+
+	user := c.MustGet("calDAVUser").(entities.User)
+
+	segments := make([]string, 0)
+	for _, segment := range strings.Split(c.Param("path"), "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	if len(segments) > 0 && segments[0] != user.Id.String() {
+		c.Status(http.StatusForbidden)
+		return
+	}
+	if len(segments) > 1 && segments[1] != calDAVCalendarName || len(segments) > 3 {
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case len(segments) <= 1:
+		h.serveHome(c, user)
+	case len(segments) == 2:
+		h.serveCalendar(c, user)
+	default:
+		h.serveObject(c, user, segments[2])
+	}
+}
+
+func (h *calDAVHandler) serveHome(c *gin.Context, user entities.User) {
+	if c.Request.Method != "PROPFIND" {
+		c.Status(http.StatusMethodNotAllowed)
+		return
+	}
+
+	request, err := parsePropfind(c.Request.Body)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	responses := []davResponse{
+		newDavResponse(principalHref(user), h.homeProps(user), request),
+	}
+
+	if c.GetHeader("Depth") != "0" {
+		calendarProps, err := h.calendarProps(user)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		responses = append(responses, newDavResponse(calendarHref(user), calendarProps, request))
+	}
+
+	writeMultistatus(c, responses)
+}
+
+func (h *calDAVHandler) serveCalendar(c *gin.Context, user entities.User) {
+	switch c.Request.Method {
+	case "PROPFIND":
+		request, err := parsePropfind(c.Request.Body)
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		calendarProps, err := h.calendarProps(user)
+		if err != nil {
+			log.Println(err)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		responses := []davResponse{newDavResponse(calendarHref(user), calendarProps, request)}
+
+		if c.GetHeader("Depth") != "0" {
+			objects, err := h.service.GetObjects(user.Id, nil, nil)
+			if err != nil {
+				log.Println(err)
+				c.Status(http.StatusInternalServerError)
+				return
+			}
+			for _, object := range objects {
+				responses = append(responses, newDavResponse(objectHref(user, object.Href), objectProps(object), request))
+			}
+		}
+
+		writeMultistatus(c, responses)
+	case "REPORT":
+		h.report(c, user)
+	default:
+		c.Status(http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *calDAVHandler) report(c *gin.Context, user entities.User) {
+	var request calDAVReportRequest
+	if err := xml.NewDecoder(c.Request.Body).Decode(&request); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	propfind := davPropfindRequest{Prop: request.Prop}
+	responses := make([]davResponse, 0)
+
+	switch request.XMLName {
+	case xml.Name{Space: calDAVNamespace, Local: "calendar-multiget"}:
+		for _, href := range request.Hrefs {
+			name := href[strings.LastIndex(href, "/")+1:]
+			object, err := h.service.GetObject(user.Id, name)
+			if errors.Is(err, services.ErrCalDAVNotFound) {
+				responses = append(responses, davResponse{Href: href, Status: http.StatusNotFound})
+				continue
+			}
+			if err != nil {
+				log.Println(err)
+				c.Status(http.StatusInternalServerError)
+				return
+			}
+			responses = append(responses, newDavResponse(objectHref(user, object.Href), objectProps(object), propfind))
+		}
+	case xml.Name{Space: calDAVNamespace, Local: "calendar-query"}:
+		filter := request.Filter.CompFilter
+		if filter.Name != "" && filter.Name != "VCALENDAR" {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		var start, end *time.Time
+		for _, child := range filter.CompFilters {
+			if child.Name != "VEVENT" {
+				continue
+			}
+			if child.TimeRange != nil {
+				start = parseTimeRangeBound(child.TimeRange.Start)
+				end = parseTimeRangeBound(child.TimeRange.End)
+			}
+		}
+
+		wantsEvents := len(filter.CompFilters) == 0
+		for _, child := range filter.CompFilters {
+			wantsEvents = wantsEvents || child.Name == "VEVENT"
+		}
+
+		if wantsEvents {
+			objects, err := h.service.GetObjects(user.Id, start, end)
+			if err != nil {
+				log.Println(err)
+				c.Status(http.StatusInternalServerError)
+				return
+			}
+			for _, object := range objects {
+				responses = append(responses, newDavResponse(objectHref(user, object.Href), objectProps(object), propfind))
+			}
+		}
+	default:
+		c.Status(http.StatusNotImplemented)
+		return
+	}
+
+	writeMultistatus(c, responses)
+}
+
+func (h *calDAVHandler) serveObject(c *gin.Context, user entities.User, name string) {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead:
+		object, err := h.service.GetObject(user.Id, name)
+		if err != nil {
+			writeCalDAVError(c, err)
+			return
+		}
+
+		c.Header("ETag", object.ETag)
+		c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(object.Data))
+	case "PROPFIND":
+		request, err := parsePropfind(c.Request.Body)
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		object, err := h.service.GetObject(user.Id, name)
+		if err != nil {
+			writeCalDAVError(c, err)
+			return
+		}
+
+		writeMultistatus(c, []davResponse{newDavResponse(objectHref(user, object.Href), objectProps(object), request)})
+	case http.MethodPut:
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, calDAVMaxObjectBytes+1))
+		if err != nil || len(body) > calDAVMaxObjectBytes {
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		object, created, err := h.service.PutObject(user.Id, name, string(body), c.GetHeader("If-Match"), c.GetHeader("If-None-Match"))
+		if err != nil {
+			writeCalDAVError(c, err)
+			return
+		}
+
+		c.Header("ETag", object.ETag)
+		if created {
+			c.Status(http.StatusCreated)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	case http.MethodDelete:
+		if err := h.service.DeleteObject(user.Id, name, c.GetHeader("If-Match")); err != nil {
+			writeCalDAVError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	default:
+		c.Status(http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *calDAVHandler) homeProps(user entities.User) map[xml.Name]string {
+	principal := "<d:href>" + xmlEscape(principalHref(user)) + "</d:href>"
+
+	return map[xml.Name]string{
+		{Space: davNamespace, Local: "resourcetype"}:                 "<d:collection/><d:principal/>",
+		{Space: davNamespace, Local: "displayname"}:                  xmlEscape(user.Name),
+		{Space: davNamespace, Local: "current-user-principal"}:       principal,
+		{Space: davNamespace, Local: "principal-URL"}:                principal,
+		{Space: calDAVNamespace, Local: "calendar-home-set"}:         principal,
+		{Space: calDAVNamespace, Local: "calendar-user-address-set"}: "<d:href>mailto:" + xmlEscape(user.Email) + "</d:href>",
+	}
+}
+
+func (h *calDAVHandler) calendarProps(user entities.User) (map[xml.Name]string, error) {
+	ctag, err := h.service.GetCTag(user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	principal := "<d:href>" + xmlEscape(principalHref(user)) + "</d:href>"
+
+	return map[xml.Name]string{
+		{Space: davNamespace, Local: "resourcetype"}:                        "<d:collection/><c:calendar/>",
+		{Space: davNamespace, Local: "displayname"}:                         "RUsman",
+		{Space: davNamespace, Local: "current-user-principal"}:              principal,
+		{Space: davNamespace, Local: "owner"}:                               principal,
+		{Space: davNamespace, Local: "current-user-privilege-set"}:          "<d:privilege><d:read/></d:privilege><d:privilege><d:write/></d:privilege>",
+		{Space: calServerNamespace, Local: "getctag"}:                       xmlEscape(ctag),
+		{Space: davNamespace, Local: "getetag"}:                             xmlEscape(`"` + ctag + `"`),
+		{Space: calDAVNamespace, Local: "supported-calendar-component-set"}: `<c:comp name="VEVENT"/>`,
+		{Space: calDAVNamespace, Local: "supported-calendar-data"}:          `<c:calendar-data content-type="text/calendar" version="2.0"/>`,
+	}, nil
+}
+
+func objectProps(object entities.CalDAVObject) map[xml.Name]string {
+	return map[xml.Name]string{
+		{Space: davNamespace, Local: "resourcetype"}:     "",
+		{Space: davNamespace, Local: "getetag"}:          xmlEscape(object.ETag),
+		{Space: davNamespace, Local: "getcontenttype"}:   "text/calendar; charset=utf-8; component=vevent",
+		{Space: calDAVNamespace, Local: "calendar-data"}: xmlEscape(object.Data),
+	}
+}
+
+func principalHref(user entities.User) string {
+	return calDAVPrefix + "/" + user.Id.String() + "/"
+}
+
+func calendarHref(user entities.User) string {
+	return principalHref(user) + calDAVCalendarName + "/"
+}
+
+func objectHref(user entities.User, name string) string {
+	return calendarHref(user) + name
+}
+
+func parseTimeRangeBound(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	// Time ranges are UTC instants; schedules are compared as wall-clock times.
+	t, _, err := utils.ParseICalDateTime(utils.ICalProperty{Value: value}, utils.ScheduleLocation())
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+func writeCalDAVError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrCalDAVNotFound):
+		c.Status(http.StatusNotFound)
+	case errors.Is(err, services.ErrCalDAVPreconditionFailed), errors.Is(err, services.ErrVersionConflict):
+		c.Status(http.StatusPreconditionFailed)
+	case errors.Is(err, utils.ErrInvalidICal):
+		c.String(http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrRoomUnavailable):
+		c.String(http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrScheduleForbidden):
+		c.String(http.StatusForbidden, err.Error())
+	default:
+		log.Println(err)
+		c.Status(http.StatusInternalServerError)
+	}
+}
+
+type davPropName struct {
+	XMLName xml.Name
+}
+
+type davPropNames struct {
+	Names []davPropName `xml:",any"`
+}
+
+type davPropfindRequest struct {
+	XMLName  xml.Name      `xml:"DAV: propfind"`
+	AllProp  *struct{}     `xml:"DAV: allprop"`
+	PropName *struct{}     `xml:"DAV: propname"`
+	Prop     *davPropNames `xml:"DAV: prop"`
+}
+
+type calDAVTimeRange struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+type calDAVCompFilter struct {
+	Name        string             `xml:"name,attr"`
+	TimeRange   *calDAVTimeRange   `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+	CompFilters []calDAVCompFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type calDAVReportRequest struct {
+	XMLName xml.Name
+	Prop    *davPropNames `xml:"DAV: prop"`
+	Hrefs   []string      `xml:"DAV: href"`
+	Filter  struct {
+		CompFilter calDAVCompFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	} `xml:"urn:ietf:params:xml:ns:caldav filter"`
+}
+
+// parsePropfind treats an empty body as allprop, as RFC 4918 requires.
+func parsePropfind(body io.Reader) (davPropfindRequest, error) {
+	var request davPropfindRequest
+
+	err := xml.NewDecoder(body).Decode(&request)
+	if errors.Is(err, io.EOF) {
+		return davPropfindRequest{}, nil
+	}
+
+	return request, err
+}
+
+type davResponse struct {
+	Href    string
+	Status  int
+	Found   map[xml.Name]string
+	Missing []xml.Name
+}
+
+func newDavResponse(href string, props map[xml.Name]string, request davPropfindRequest) davResponse {
+	response := davResponse{Href: href, Found: map[xml.Name]string{}}
+
+	if request.Prop == nil || request.AllProp != nil {
+		for name, value := range props {
+			// calendar-data is expensive and only returned when asked for explicitly.
+			if name.Local == "calendar-data" {
+				continue
+			}
+			if request.PropName != nil {
+				value = ""
+			}
+			response.Found[name] = value
+		}
+		return response
+	}
+
+	for _, requested := range request.Prop.Names {
+		if value, ok := props[requested.XMLName]; ok {
+			response.Found[requested.XMLName] = value
+			continue
+		}
+		response.Missing = append(response.Missing, requested.XMLName)
+	}
+
+	return response
+}
+
+func writeMultistatus(c *gin.Context, responses []davResponse) {
+	var b strings.Builder
+
+	b.WriteString(`<?xml version="1.0" encoding="utf-8"?>`)
+	b.WriteString(`<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/">`)
+	for _, response := range responses {
+		b.WriteString("<d:response><d:href>" + xmlEscape(response.Href) + "</d:href>")
+		if response.Status != 0 {
+			b.WriteString("<d:status>" + statusLine(response.Status) + "</d:status></d:response>")
+			continue
+		}
+		if len(response.Found) > 0 {
+			b.WriteString("<d:propstat><d:prop>")
+			for name, value := range response.Found {
+				writeDavProp(&b, name, value)
+			}
+			b.WriteString("</d:prop><d:status>" + statusLine(http.StatusOK) + "</d:status></d:propstat>")
+		}
+		if len(response.Missing) > 0 {
+			b.WriteString("<d:propstat><d:prop>")
+			for _, name := range response.Missing {
+				writeDavProp(&b, name, "")
+			}
+			b.WriteString("</d:prop><d:status>" + statusLine(http.StatusNotFound) + "</d:status></d:propstat>")
+		}
+		b.WriteString("</d:response>")
+	}
+	b.WriteString("</d:multistatus>")
+
+	c.Data(http.StatusMultiStatus, "application/xml; charset=utf-8", []byte(b.String()))
+}
+
+func writeDavProp(b *strings.Builder, name xml.Name, value string) {
+	tag := ""
+	attr := ""
+	switch name.Space {
+	case davNamespace:
+		tag = "d:" + name.Local
+	case calDAVNamespace:
+		tag = "c:" + name.Local
+	case calServerNamespace:
+		tag = "cs:" + name.Local
+	default:
+		tag = "x:" + name.Local
+		attr = ` xmlns:x="` + xmlEscape(name.Space) + `"`
+	}
+
+	if value == "" {
+		b.WriteString("<" + tag + attr + "/>")
+		return
+	}
+	b.WriteString("<" + tag + attr + ">" + value + "</" + tag + ">")
+}
+
+func statusLine(code int) string {
+	return "HTTP/1.1 " + strconv.Itoa(code) + " " + http.StatusText(code)
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,497 @@
+package handlers
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const testCalDAVToken = "rus_test-token"
+
+func TestMain(m *testing.M) {
+	// Schedule times are wall-clock values in this zone; a non-UTC zone makes
+	// any conversion to instants show up as a shifted time.
+	os.Setenv("SCHEDULE_TIMEZONE", "Asia/Jakarta")
+	os.Exit(m.Run())
+}
+
+// fakeCalDAVService keeps calendar objects in memory and applies the same
+// If-Match / If-None-Match rules as the real service.
+type fakeCalDAVService struct {
+	objects map[string]entities.CalDAVObject
+}
+
+func (s *fakeCalDAVService) GetCTag(userId uuid.UUID) (string, error) {
+	return "ctag-" + uuid.NewSHA1(userId, []byte("ctag")).String(), nil
+}
+
+func (s *fakeCalDAVService) GetObjects(userId uuid.UUID, start *time.Time, end *time.Time) ([]entities.CalDAVObject, error) {
+	objects := make([]entities.CalDAVObject, 0, len(s.objects))
+	for _, object := range s.objects {
+		if start != nil && !object.Schedule.EndTime.After(*start) {
+			continue
+		}
+		if end != nil && !object.Schedule.StartTime.Before(*end) {
+			continue
+		}
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+func (s *fakeCalDAVService) GetObject(userId uuid.UUID, href string) (entities.CalDAVObject, error) {
+	object, ok := s.objects[href]
+	if !ok {
+		return entities.CalDAVObject{}, services.ErrCalDAVNotFound
+	}
+
+	return object, nil
+}
+
+func (s *fakeCalDAVService) PutObject(userId uuid.UUID, href string, data string, ifMatch string, ifNoneMatch string) (entities.CalDAVObject, bool, error) {
+	existing, exists := s.objects[href]
+	if exists && (ifNoneMatch == "*" || (ifMatch != "" && ifMatch != "*" && ifMatch != existing.ETag)) {
+		return entities.CalDAVObject{}, false, services.ErrCalDAVPreconditionFailed
+	}
+	if !exists && ifMatch != "" {
+		return entities.CalDAVObject{}, false, services.ErrCalDAVPreconditionFailed
+	}
+
+	object := newFakeCalDAVObject(href, data, existing.Schedule)
+	s.objects[href] = object
+	return object, !exists, nil
+}
+
+func (s *fakeCalDAVService) DeleteObject(userId uuid.UUID, href string, ifMatch string) error {
+	existing, ok := s.objects[href]
+	if !ok {
+		return services.ErrCalDAVNotFound
+	}
+	if ifMatch != "" && ifMatch != "*" && ifMatch != existing.ETag {
+		return services.ErrCalDAVPreconditionFailed
+	}
+
+	delete(s.objects, href)
+	return nil
+}
+
+func newFakeCalDAVObject(href string, data string, schedule entities.Schedule) entities.CalDAVObject {
+	sum := sha1.Sum([]byte(data))
+	return entities.CalDAVObject{
+		Href:     href,
+		ETag:     `"` + hex.EncodeToString(sum[:]) + `"`,
+		Data:     data,
+		Schedule: schedule,
+	}
+}
+
+// fakePersonalTokenService accepts testCalDAVToken for one user.
+type fakePersonalTokenService struct {
+	user entities.User
+}
+
+func (s *fakePersonalTokenService) CreatePersonalToken(userId uuid.UUID, name string) (entities.PersonalTokenResponse, error) {
+	return entities.PersonalTokenResponse{}, nil
+}
+
+func (s *fakePersonalTokenService) GetPersonalTokensByUser(userId uuid.UUID) ([]entities.PersonalToken, error) {
+	return nil, nil
+}
+
+func (s *fakePersonalTokenService) RevokePersonalToken(id uuid.UUID, userId uuid.UUID) error {
+	return nil
+}
+
+func (s *fakePersonalTokenService) Authenticate(token string) (entities.User, error) {
+	if token != testCalDAVToken {
+		return entities.User{}, services.ErrInvalidToken
+	}
+
+	return s.user, nil
+}
+
+// fakeScheduleService keeps schedules in memory. Only the methods the CalDAV
+// service calls are implemented.
+type fakeScheduleService struct {
+	services.ScheduleService
+	schedules map[uuid.UUID]entities.Schedule
+}
+
+func (s *fakeScheduleService) CreateNewSchedule(schedule entities.Schedule) error {
+	s.schedules[schedule.Id] = schedule
+	return nil
+}
+
+func (s *fakeScheduleService) GetScheduleByID(id uuid.UUID) (entities.Schedule, error) {
+	schedule, ok := s.schedules[id]
+	if !ok {
+		return entities.Schedule{}, gorm.ErrRecordNotFound
+	}
+	return schedule, nil
+}
+
+func (s *fakeScheduleService) GetAllSchedules(userID string) ([]entities.Schedule, error) {
+	schedules := make([]entities.Schedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		if schedule.UserId.String() == userID {
+			schedules = append(schedules, schedule)
+		}
+	}
+	return schedules, nil
+}
+
+func (s *fakeScheduleService) UpdateSchedule(userId uuid.UUID, schedule entities.Schedule) error {
+	s.schedules[schedule.Id] = schedule
+	return nil
+}
+
+type fakeCalDAVResourceRepository struct {
+	resources []entities.CalDAVResource
+}
+
+func (r *fakeCalDAVResourceRepository) CreateNewCalDAVResource(model entities.CalDAVResource) error {
+	r.resources = append(r.resources, model)
+	return nil
+}
+
+func (r *fakeCalDAVResourceRepository) FindCalDAVResourceByHref(userId uuid.UUID, href string) (entities.CalDAVResource, error) {
+	for _, resource := range r.resources {
+		if resource.UserId == userId && resource.Href == href {
+			return resource, nil
+		}
+	}
+	return entities.CalDAVResource{}, gorm.ErrRecordNotFound
+}
+
+func (r *fakeCalDAVResourceRepository) GetCalDAVResourcesByUser(userId uuid.UUID) ([]entities.CalDAVResource, error) {
+	return r.resources, nil
+}
+
+func (r *fakeCalDAVResourceRepository) DeleteCalDAVResourceBySchedule(scheduleId uuid.UUID) error {
+	return nil
+}
+
+type calDAVTestServer struct {
+	router  *gin.Engine
+	user    entities.User
+	service *fakeCalDAVService
+}
+
+func newCalDAVTestServer(t *testing.T) *calDAVTestServer {
+	t.Helper()
+
+	server := newCalDAVRouter(t, nil)
+	morning := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	server.service.objects["lecture.ics"] = newFakeCalDAVObject("lecture.ics", "BEGIN:VCALENDAR\r\nSUMMARY:Lecture\r\nEND:VCALENDAR\r\n",
+		entities.Schedule{Title: "Lecture", StartTime: morning, EndTime: morning.Add(2 * time.Hour)})
+	server.service.objects["lab.ics"] = newFakeCalDAVObject("lab.ics", "BEGIN:VCALENDAR\r\nSUMMARY:Lab\r\nEND:VCALENDAR\r\n",
+		entities.Schedule{Title: "Lab", StartTime: morning.AddDate(0, 0, 7), EndTime: morning.AddDate(0, 0, 7).Add(time.Hour)})
+
+	return server
+}
+
+// newCalDAVRouter serves calDAVService, or the in-memory fake when it is nil.
+func newCalDAVRouter(t *testing.T, calDAVService services.CalDAVService) *calDAVTestServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	user := entities.User{Id: uuid.New(), Name: "Budi", Email: "budi@example.com", IsActive: true}
+	service := &fakeCalDAVService{objects: map[string]entities.CalDAVObject{}}
+	if calDAVService == nil {
+		calDAVService = service
+	}
+
+	handler := &calDAVHandler{service: calDAVService, tokenService: &fakePersonalTokenService{user: user}}
+
+	router := gin.New()
+	calDAV := router.Group("/caldav", handler.Authenticate)
+	for _, method := range CalDAVMethods {
+		calDAV.Handle(method, "/*path", handler.Serve)
+	}
+
+	return &calDAVTestServer{router: router, user: user, service: service}
+}
+
+func (s *calDAVTestServer) do(method string, path string, body string, headers map[string]string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	request.SetBasicAuth(s.user.Email, testCalDAVToken)
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	recorder := httptest.NewRecorder()
+	s.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func (s *calDAVTestServer) calendarPath() string {
+	return "/caldav/" + s.user.Id.String() + "/calendar/"
+}
+
+func TestCalDAVRejectsMissingOrInvalidToken(t *testing.T) {
+	server := newCalDAVTestServer(t)
+	path := "/caldav/" + server.user.Id.String() + "/"
+
+	cases := map[string]func(*http.Request){
+		"missing":          func(r *http.Request) {},
+		"not a rus_ token": func(r *http.Request) { r.SetBasicAuth(server.user.Email, "password") },
+		"unknown token":    func(r *http.Request) { r.SetBasicAuth(server.user.Email, "rus_unknown") },
+		"wrong username":   func(r *http.Request) { r.SetBasicAuth("someone@example.com", testCalDAVToken) },
+		"unknown bearer":   func(r *http.Request) { r.Header.Set("Authorization", "Bearer rus_unknown") },
+	}
+
+	for name, authorize := range cases {
+		t.Run(name, func(t *testing.T) {
+			request := httptest.NewRequest("PROPFIND", path, nil)
+			authorize(request)
+
+			recorder := httptest.NewRecorder()
+			server.router.ServeHTTP(recorder, request)
+
+			if recorder.Code != http.StatusUnauthorized {
+				t.Fatalf("expected 401, got %d", recorder.Code)
+			}
+			if recorder.Header().Get("WWW-Authenticate") == "" {
+				t.Fatal("expected a WWW-Authenticate challenge")
+			}
+		})
+	}
+
+	request := httptest.NewRequest("PROPFIND", path, nil)
+	request.Header.Set("Authorization", "Bearer "+testCalDAVToken)
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusMultiStatus {
+		t.Fatalf("expected a valid bearer token to be accepted, got %d", recorder.Code)
+	}
+}
+
+func TestCalDAVPropfindHome(t *testing.T) {
+	server := newCalDAVTestServer(t)
+
+	body := `<?xml version="1.0"?>
+<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:current-user-principal/><c:calendar-home-set/><d:unknown-prop/></d:prop>
+</d:propfind>`
+	recorder := server.do("PROPFIND", "/caldav/"+server.user.Id.String()+"/", body, map[string]string{"Depth": "1"})
+
+	if recorder.Code != http.StatusMultiStatus {
+		t.Fatalf("expected 207, got %d", recorder.Code)
+	}
+
+	response := recorder.Body.String()
+	for _, want := range []string{
+		"<d:href>/caldav/" + server.user.Id.String() + "/</d:href>",
+		"<d:href>" + server.calendarPath() + "</d:href>",
+		"<c:calendar-home-set>",
+		"<d:unknown-prop/>",
+		"HTTP/1.1 404 Not Found",
+	} {
+		if !strings.Contains(response, want) {
+			t.Fatalf("expected %q in response:\n%s", want, response)
+		}
+	}
+
+	depthZero := server.do("PROPFIND", "/caldav/"+server.user.Id.String()+"/", body, map[string]string{"Depth": "0"})
+	if strings.Contains(depthZero.Body.String(), "<d:href>"+server.calendarPath()+"</d:href>") {
+		t.Fatal("expected Depth: 0 to leave out the calendar collection")
+	}
+
+	other := server.do("PROPFIND", "/caldav/"+uuid.New().String()+"/", body, nil)
+	if other.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for another user's home, got %d", other.Code)
+	}
+}
+
+func TestCalDAVReportMultiget(t *testing.T) {
+	server := newCalDAVTestServer(t)
+
+	body := `<?xml version="1.0"?>
+<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <d:href>` + server.calendarPath() + `lecture.ics</d:href>
+  <d:href>` + server.calendarPath() + `missing.ics</d:href>
+</c:calendar-multiget>`
+	recorder := server.do("REPORT", server.calendarPath(), body, map[string]string{"Depth": "1"})
+
+	if recorder.Code != http.StatusMultiStatus {
+		t.Fatalf("expected 207, got %d", recorder.Code)
+	}
+
+	response := recorder.Body.String()
+	lecture := server.service.objects["lecture.ics"]
+	for _, want := range []string{
+		xmlEscape(lecture.ETag),
+		"SUMMARY:Lecture",
+		"<d:href>" + server.calendarPath() + "missing.ics</d:href><d:status>HTTP/1.1 404 Not Found</d:status>",
+	} {
+		if !strings.Contains(response, want) {
+			t.Fatalf("expected %q in response:\n%s", want, response)
+		}
+	}
+	if strings.Contains(response, "SUMMARY:Lab") {
+		t.Fatal("expected only the requested objects")
+	}
+}
+
+func TestCalDAVReportQuery(t *testing.T) {
+	server := newCalDAVTestServer(t)
+
+	body := `<?xml version="1.0"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/></d:prop>
+  <c:filter>
+    <c:comp-filter name="VCALENDAR">
+      <c:comp-filter name="VEVENT">
+        <c:time-range start="20250310T000000Z" end="20250311T000000Z"/>
+      </c:comp-filter>
+    </c:comp-filter>
+  </c:filter>
+</c:calendar-query>`
+	recorder := server.do("REPORT", server.calendarPath(), body, map[string]string{"Depth": "1"})
+
+	if recorder.Code != http.StatusMultiStatus {
+		t.Fatalf("expected 207, got %d", recorder.Code)
+	}
+
+	response := recorder.Body.String()
+	if !strings.Contains(response, server.calendarPath()+"lecture.ics") {
+		t.Fatalf("expected the lecture inside the time range:\n%s", response)
+	}
+	if strings.Contains(response, server.calendarPath()+"lab.ics") {
+		t.Fatalf("expected the lab outside the time range to be left out:\n%s", response)
+	}
+	if strings.Contains(response, "calendar-data") {
+		t.Fatal("expected calendar-data only when it is requested")
+	}
+
+	todos := strings.Replace(body, `name="VEVENT"`, `name="VTODO"`, 1)
+	recorder = server.do("REPORT", server.calendarPath(), todos, map[string]string{"Depth": "1"})
+	if strings.Contains(recorder.Body.String(), "<d:response>") {
+		t.Fatalf("expected no events for a VTODO query:\n%s", recorder.Body.String())
+	}
+}
+
+func TestCalDAVPutPreconditions(t *testing.T) {
+	server := newCalDAVTestServer(t)
+	event := "BEGIN:VCALENDAR\r\nSUMMARY:Study group\r\nEND:VCALENDAR\r\n"
+	path := server.calendarPath() + "study.ics"
+
+	created := server.do(http.MethodPut, path, event, map[string]string{"If-None-Match": "*"})
+	if created.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", created.Code)
+	}
+	etag := created.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag on create")
+	}
+
+	again := server.do(http.MethodPut, path, event, map[string]string{"If-None-Match": "*"})
+	if again.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412 when If-None-Match: * hits an existing object, got %d", again.Code)
+	}
+
+	updated := server.do(http.MethodPut, path, strings.Replace(event, "Study group", "Study group (moved)", 1), map[string]string{"If-Match": etag})
+	if updated.Code != http.StatusNoContent {
+		t.Fatalf("expected 204 on update, got %d", updated.Code)
+	}
+	if updated.Header().Get("ETag") == etag {
+		t.Fatal("expected the ETag to change after an update")
+	}
+
+	stale := server.do(http.MethodPut, path, event, map[string]string{"If-Match": etag})
+	if stale.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412 for a stale ETag, got %d", stale.Code)
+	}
+
+	missing := server.do(http.MethodPut, server.calendarPath()+"new.ics", event, map[string]string{"If-Match": etag})
+	if missing.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412 for If-Match on a missing object, got %d", missing.Code)
+	}
+}
+
+func TestCalDAVDelete(t *testing.T) {
+	server := newCalDAVTestServer(t)
+	path := server.calendarPath() + "lecture.ics"
+
+	stale := server.do(http.MethodDelete, path, "", map[string]string{"If-Match": `"stale"`})
+	if stale.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412 for a stale ETag, got %d", stale.Code)
+	}
+
+	deleted := server.do(http.MethodDelete, path, "", map[string]string{"If-Match": server.service.objects["lecture.ics"].ETag})
+	if deleted.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", deleted.Code)
+	}
+
+	if gone := server.do(http.MethodGet, path, "", nil); gone.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 after delete, got %d", gone.Code)
+	}
+	if again := server.do(http.MethodDelete, path, "", nil); again.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 when deleting twice, got %d", again.Code)
+	}
+}
+
+func TestCalDAVRoundTripKeepsWallClockTimes(t *testing.T) {
+	schedules := &fakeScheduleService{schedules: map[uuid.UUID]entities.Schedule{}}
+	server := newCalDAVRouter(t, services.NewCalDAVServiceWith(schedules, &fakeCalDAVResourceRepository{}))
+
+	// The same 09:00-10:30 class in Jakarta, written three ways.
+	cases := map[string][2]string{
+		"floating": {"DTSTART:20250310T090000", "DTEND:20250310T103000"},
+		"tzid":     {"DTSTART;TZID=Asia/Jakarta:20250310T090000", "DTEND;TZID=Asia/Jakarta:20250310T103000"},
+		"utc":      {"DTSTART:20250310T020000Z", "DTEND:20250310T033000Z"},
+	}
+
+	wantStart := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2025, 3, 10, 10, 30, 0, 0, time.UTC)
+
+	for name, times := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := server.calendarPath() + name + ".ics"
+			event := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nUID:" + name + "\r\n" +
+				times[0] + "\r\n" + times[1] + "\r\nSUMMARY:Algorithms\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+
+			if put := server.do(http.MethodPut, path, event, nil); put.Code != http.StatusCreated {
+				t.Fatalf("expected 201, got %d: %s", put.Code, put.Body.String())
+			}
+
+			get := server.do(http.MethodGet, path, "", nil)
+			if get.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", get.Code)
+			}
+			for _, want := range []string{"DTSTART:20250310T090000\r\n", "DTEND:20250310T103000\r\n"} {
+				if !strings.Contains(get.Body.String(), want) {
+					t.Fatalf("expected %q in:\n%s", want, get.Body.String())
+				}
+			}
+
+			// Saving the exported event back must not move it.
+			update := server.do(http.MethodPut, path, get.Body.String(), map[string]string{"If-Match": get.Header().Get("ETag")})
+			if update.Code != http.StatusNoContent {
+				t.Fatalf("expected 204, got %d: %s", update.Code, update.Body.String())
+			}
+			if again := server.do(http.MethodGet, path, "", nil); again.Body.String() != get.Body.String() {
+				t.Fatalf("expected an unchanged event after the round trip, got:\n%s", again.Body.String())
+			}
+		})
+	}
+
+	for _, schedule := range schedules.schedules {
+		if !schedule.StartTime.Equal(wantStart) || !schedule.EndTime.Equal(wantEnd) {
+			t.Fatalf("expected %v-%v to be stored, got %v-%v", wantStart, wantEnd, schedule.StartTime, schedule.EndTime)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CalendarHandler interface {
+	GetCalendar(c *gin.Context)
+	Export(c *gin.Context)
+}
+
+type calendarHandler struct {
+	service      services.CalendarService
+	tokenService services.PersonalTokenService
+}
+
+func NewCalendarHandler() CalendarHandler {
+	return &calendarHandler{
+		service:      services.NewCalendarService(),
+		tokenService: services.NewPersonalTokenService(),
+	}
+}
+
+func (h *calendarHandler) GetCalendar(c *gin.Context) {
+	type CalendarRequest struct {
+		UserId    uuid.UUID `json:"userId"`
+		StartDate string    `json:"startDate"`
+		EndDate   string    `json:"endDate"`
+	}
+
+	var calendarRequest CalendarRequest
+
+	if err := c.ShouldBindJSON(&calendarRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", calendarRequest.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start date"})
+		return
+	}
+
+	endDate, err := time.Parse("2006-01-02", calendarRequest.EndDate)
+	if err != nil || endDate.Before(startDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end date"})
+		return
+	}
+
+	// The end date is inclusive.
+	calendar, err := h.service.GetCalendar(calendarRequest.UserId, startDate, endDate.AddDate(0, 0, 1))
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// Export serves the caller's calendar as an .ics file. Calendar apps subscribe with
+// ?token=<personal token>; other clients may send it as a Bearer token instead.
+func (h *calendarHandler) Export(c *gin.Context) {
+	token := c.Query("token")
+	if bearer, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); found {
+		token = strings.TrimSpace(bearer)
+	}
+
+	user, err := h.tokenService.Authenticate(token)
+	if err != nil {
+		if !errors.Is(err, services.ErrInvalidToken) {
+			log.Println(err)
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		return
+	}
+
+	data, err := h.service.ExportICal(user.Id)
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="calendar.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(data))
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CommentHandler interface {
+	Create(c *gin.Context)
+	GetAllBySchedule(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	SetThreadLocked(c *gin.Context)
+}
+
+type commentHandler struct {
+	service services.CommentService
+}
+
+func NewCommentHandler() CommentHandler {
+	return &commentHandler{
+		service: services.NewCommentService(),
+	}
+}
+
+func (h *commentHandler) Create(c *gin.Context) {
+	var Comment entities.Comment
+
+	if err := c.ShouldBindJSON(&Comment); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	comment, err := h.service.CreateNewComment(Comment)
+	if err != nil {
+		writeCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// GetAllBySchedule reads ?userId=, ?page= (1-based) and ?pageSize=.
+func (h *commentHandler) GetAllBySchedule(c *gin.Context) {
+	scheduleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "0"))
+
+	comments, err := h.service.GetCommentsBySchedule(scheduleID, userID, page, pageSize)
+	if err != nil {
+		writeCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+func (h *commentHandler) Update(c *gin.Context) {
+	type UpdateCommentRequest struct {
+		UserId   uuid.UUID   `json:"userId"`
+		Body     string      `json:"body"`
+		Mentions []uuid.UUID `json:"mentions"`
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	var updateRequest UpdateCommentRequest
+
+	if err := c.ShouldBindJSON(&updateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	comment, err := h.service.UpdateComment(id, updateRequest.UserId, updateRequest.Body, updateRequest.Mentions)
+	if err != nil {
+		writeCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+func (h *commentHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.service.DeleteComment(id, userID); err != nil {
+		writeCommentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted"})
+}
+
+func (h *commentHandler) SetThreadLocked(c *gin.Context) {
+	type LockThreadRequest struct {
+		ScheduleId uuid.UUID `json:"scheduleId"`
+		UserId     uuid.UUID `json:"userId"`
+		Locked     bool      `json:"locked"`
+	}
+
+	var lockRequest LockThreadRequest
+
+	if err := c.ShouldBindJSON(&lockRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := h.service.SetThreadLocked(lockRequest.ScheduleId, lockRequest.UserId, lockRequest.Locked); err != nil {
+		writeCommentError(c, err)
+		return
+	}
+
+	if lockRequest.Locked {
+		c.JSON(http.StatusOK, gin.H{"message": "Comment thread locked"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment thread unlocked"})
+}
+
+func writeCommentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+	case errors.Is(err, services.ErrScheduleForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrCommentThreadLocked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidComment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
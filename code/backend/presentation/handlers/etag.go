@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errInvalidIfMatch = errors.New("invalid If-Match header")
+
+func versionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ifMatchVersion reads the version a client expects from its If-Match header.
+// It reports false when the header is absent or "*", which matches any version.
+func ifMatchVersion(c *gin.Context) (int, bool, error) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return 0, false, nil
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(value, "W/"), `"`))
+	if err != nil || version <= 0 {
+		return 0, false, errInvalidIfMatch
+	}
+
+	return version, true, nil
+}
+
+// writeVersionConflict answers a stale update with the record as it is now. A
+// failed If-Match is a 412; a stale version in the body is a 409.
+func writeVersionConflict(c *gin.Context, conditional bool, version int, current interface{}) {
+	status := http.StatusConflict
+	if conditional {
+		status = http.StatusPreconditionFailed
+	}
+
+	c.Header("ETag", versionETag(version))
+	c.JSON(status, gin.H{"error": "The record was changed by someone else", "current": current})
+}
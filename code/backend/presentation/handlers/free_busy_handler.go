@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type FreeBusyHandler interface {
+	GetCommonFreeTime(c *gin.Context)
+}
+
+type freeBusyHandler struct {
+	service services.FreeBusyService
+}
+
+func NewFreeBusyHandler() FreeBusyHandler {
+	return &freeBusyHandler{
+		service: services.NewFreeBusyService(),
+	}
+}
+
+func (h *freeBusyHandler) GetCommonFreeTime(c *gin.Context) {
+	type FreeTimeRequest struct {
+		UserIds     []uuid.UUID `json:"userIds"`
+		StartDate   string      `json:"startDate"`
+		EndDate     string      `json:"endDate"`
+		DayStart    string      `json:"dayStart"`
+		DayEnd      string      `json:"dayEnd"`
+		MinDuration int         `json:"minDuration"` // minutes
+		Timezone    string      `json:"timezone"`
+	}
+
+	var freeTimeRequest FreeTimeRequest
+
+	if err := c.ShouldBindJSON(&freeTimeRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	loc := time.UTC
+	if freeTimeRequest.Timezone != "" {
+		l, err := time.LoadLocation(freeTimeRequest.Timezone)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timezone"})
+			return
+		}
+		loc = l
+	}
+
+	// Schedules hold wall-clock times, so the zone only decides which date
+	// "today" is when the range is left out.
+	if freeTimeRequest.StartDate == "" {
+		freeTimeRequest.StartDate = time.Now().In(loc).Format("2006-01-02")
+	}
+	if freeTimeRequest.EndDate == "" {
+		freeTimeRequest.EndDate = freeTimeRequest.StartDate
+	}
+
+	startDate, err := time.Parse("2006-01-02", freeTimeRequest.StartDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start date"})
+		return
+	}
+
+	endDate, err := time.Parse("2006-01-02", freeTimeRequest.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end date"})
+		return
+	}
+
+	if freeTimeRequest.DayStart == "" {
+		freeTimeRequest.DayStart = "07:00"
+	}
+	if freeTimeRequest.DayEnd == "" {
+		freeTimeRequest.DayEnd = "21:00"
+	}
+
+	dayStart, err := parseClock(freeTimeRequest.DayStart)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid day start"})
+		return
+	}
+
+	dayEnd, err := parseClock(freeTimeRequest.DayEnd)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid day end"})
+		return
+	}
+
+	slots, err := h.service.GetCommonFreeTime(entities.FreeTimeQuery{
+		UserIds:     freeTimeRequest.UserIds,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		DayStart:    dayStart,
+		DayEnd:      dayEnd,
+		MinDuration: time.Duration(freeTimeRequest.MinDuration) * time.Minute,
+	})
+	if errors.Is(err, services.ErrInvalidFreeTimeQuery) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, slots)
+}
+
+// parseClock turns "07:00" (or "24:00") into an offset from midnight.
+func parseClock(value string) (time.Duration, error) {
+	if value == "24:00" {
+		return 24 * time.Hour, nil
+	}
+
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
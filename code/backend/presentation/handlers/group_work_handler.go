@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type GroupWorkHandler interface {
+	Recommend(c *gin.Context)
+	Book(c *gin.Context)
+}
+
+type groupWorkHandler struct {
+	service services.GroupWorkService
+}
+
+func NewGroupWorkHandler() GroupWorkHandler {
+	return &groupWorkHandler{
+		service: services.NewGroupWorkService(),
+	}
+}
+
+func (h *groupWorkHandler) Recommend(c *gin.Context) {
+	type RecommendRequest struct {
+		OrganizerId     uuid.UUID   `json:"organizerId"`
+		ParticipantIds  []uuid.UUID `json:"participantIds"`
+		DurationMinutes int         `json:"durationMinutes"`
+		EarliestStart   string      `json:"earliestStart"`
+		Deadline        string      `json:"deadline"`
+		Location        string      `json:"location"`
+		DayStart        string      `json:"dayStart"`
+		DayEnd          string      `json:"dayEnd"`
+		PreferredStart  string      `json:"preferredStart"`
+		PreferredEnd    string      `json:"preferredEnd"`
+		Limit           int         `json:"limit"`
+		Timezone        string      `json:"timezone"`
+	}
+
+	var recommendRequest RecommendRequest
+
+	if err := c.ShouldBindJSON(&recommendRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	loc := time.UTC
+	if recommendRequest.Timezone != "" {
+		l, err := time.LoadLocation(recommendRequest.Timezone)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timezone"})
+			return
+		}
+		loc = l
+	}
+
+	deadline, err := time.Parse("2006-01-02T15:04:05", recommendRequest.Deadline)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid deadline"})
+		return
+	}
+
+	// Schedules hold wall-clock times, so "now" is read in the caller's zone.
+	earliestStart := utils.WallClockNow(loc)
+	if recommendRequest.EarliestStart != "" {
+		earliestStart, err = time.Parse("2006-01-02T15:04:05", recommendRequest.EarliestStart)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid earliest start"})
+			return
+		}
+	}
+
+	clocks := map[string]*string{
+		"07:00": &recommendRequest.DayStart,
+		"21:00": &recommendRequest.DayEnd,
+		"09:00": &recommendRequest.PreferredStart,
+		"17:00": &recommendRequest.PreferredEnd,
+	}
+	for fallback, value := range clocks {
+		if *value == "" {
+			*value = fallback
+		}
+	}
+
+	dayStart, errDayStart := parseClock(recommendRequest.DayStart)
+	dayEnd, errDayEnd := parseClock(recommendRequest.DayEnd)
+	preferredStart, errPreferredStart := parseClock(recommendRequest.PreferredStart)
+	preferredEnd, errPreferredEnd := parseClock(recommendRequest.PreferredEnd)
+	if err := errors.Join(errDayStart, errDayEnd, errPreferredStart, errPreferredEnd); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time of day"})
+		return
+	}
+
+	recommendations, err := h.service.RecommendSlots(entities.GroupWorkQuery{
+		OrganizerId:    recommendRequest.OrganizerId,
+		ParticipantIds: recommendRequest.ParticipantIds,
+		Duration:       time.Duration(recommendRequest.DurationMinutes) * time.Minute,
+		EarliestStart:  earliestStart,
+		Deadline:       deadline,
+		Location:       recommendRequest.Location,
+		DayStart:       dayStart,
+		DayEnd:         dayEnd,
+		PreferredStart: preferredStart,
+		PreferredEnd:   preferredEnd,
+		Limit:          recommendRequest.Limit,
+	})
+	if errors.Is(err, services.ErrInvalidGroupWorkQuery) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, recommendations)
+}
+
+func (h *groupWorkHandler) Book(c *gin.Context) {
+	type BookRequest struct {
+		OrganizerId    uuid.UUID   `json:"organizerId"`
+		ParticipantIds []uuid.UUID `json:"participantIds"`
+		StartTime      string      `json:"startTime"`
+		EndTime        string      `json:"endTime"`
+		Title          string      `json:"title"`
+		Description    string      `json:"description"`
+		Location       string      `json:"location"`
+		Category       string      `json:"category"`
+		RoomId         *uuid.UUID  `json:"roomId"`
+	}
+
+	var bookRequest BookRequest
+
+	if err := c.ShouldBindJSON(&bookRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	startTime, err := time.Parse("2006-01-02T15:04:05", bookRequest.StartTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start time"})
+		return
+	}
+
+	endTime, err := time.Parse("2006-01-02T15:04:05", bookRequest.EndTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end time"})
+		return
+	}
+
+	schedule := entities.Schedule{
+		Id:          uuid.New(),
+		UserId:      bookRequest.OrganizerId,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		Title:       bookRequest.Title,
+		Description: bookRequest.Description,
+		Location:    bookRequest.Location,
+		Category:    bookRequest.Category,
+		RoomId:      bookRequest.RoomId,
+	}
+
+	err = h.service.BookSlot(schedule, bookRequest.ParticipantIds)
+	if errors.Is(err, services.ErrInvalidSchedule) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		writeScheduleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type MeetingPollHandler interface {
+	Create(c *gin.Context)
+	Get(c *gin.Context)
+	GetAllByUser(c *gin.Context)
+	Vote(c *gin.Context)
+	Finalize(c *gin.Context)
+	Cancel(c *gin.Context)
+}
+
+type meetingPollHandler struct {
+	service services.MeetingPollService
+}
+
+func NewMeetingPollHandler() MeetingPollHandler {
+	return &meetingPollHandler{
+		service: services.NewMeetingPollService(),
+	}
+}
+
+func (h *meetingPollHandler) Create(c *gin.Context) {
+	type PollOptionRequest struct {
+		StartTime string `json:"startTime"`
+		EndTime   string `json:"endTime"`
+	}
+
+	type PollRequest struct {
+		UserId      uuid.UUID           `json:"userId"`
+		Title       string              `json:"title"`
+		Description string              `json:"description"`
+		Location    string              `json:"location"`
+		RoomId      *uuid.UUID          `json:"roomId"`
+		Category    string              `json:"category"`
+		CategoryId  *uuid.UUID          `json:"categoryId"`
+		Visibility  string              `json:"visibility"`
+		InviteeIds  []uuid.UUID         `json:"inviteeIds"`
+		Options     []PollOptionRequest `json:"options"`
+	}
+
+	var pollRequest PollRequest
+
+	if err := c.ShouldBindJSON(&pollRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	options := make([]entities.MeetingPollOption, 0, len(pollRequest.Options))
+	for _, option := range pollRequest.Options {
+		startTime, err := time.Parse("2006-01-02T15:04:05", option.StartTime)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid option start time"})
+			return
+		}
+
+		endTime, err := time.Parse("2006-01-02T15:04:05", option.EndTime)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid option end time"})
+			return
+		}
+
+		options = append(options, entities.MeetingPollOption{StartTime: startTime, EndTime: endTime})
+	}
+
+	poll, err := h.service.CreateNewMeetingPoll(entities.MeetingPoll{
+		UserId:      pollRequest.UserId,
+		Title:       pollRequest.Title,
+		Description: pollRequest.Description,
+		Location:    pollRequest.Location,
+		RoomId:      pollRequest.RoomId,
+		Category:    pollRequest.Category,
+		CategoryId:  pollRequest.CategoryId,
+		Visibility:  pollRequest.Visibility,
+		InviteeIds:  pollRequest.InviteeIds,
+	}, options)
+	if err != nil {
+		writeMeetingPollError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, poll)
+}
+
+func (h *meetingPollHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid poll ID"})
+		return
+	}
+
+	userID, ok := actingUser(c)
+	if !ok {
+		return
+	}
+
+	poll, err := h.service.GetMeetingPoll(id, userID)
+	if err != nil {
+		writeMeetingPollError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, poll)
+}
+
+func (h *meetingPollHandler) GetAllByUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	polls, err := h.service.GetMeetingPollsByUser(userID)
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, polls)
+}
+
+func (h *meetingPollHandler) Vote(c *gin.Context) {
+	type VoteRequest struct {
+		PollId uuid.UUID `json:"pollId"`
+		UserId uuid.UUID `json:"userId"`
+		Votes  []struct {
+			OptionId uuid.UUID `json:"optionId"`
+			Answer   string    `json:"answer"`
+		} `json:"votes"`
+	}
+
+	var voteRequest VoteRequest
+
+	if err := c.ShouldBindJSON(&voteRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	votes := make([]entities.MeetingPollVote, 0, len(voteRequest.Votes))
+	for _, vote := range voteRequest.Votes {
+		votes = append(votes, entities.MeetingPollVote{OptionId: vote.OptionId, Answer: vote.Answer})
+	}
+
+	poll, err := h.service.Vote(voteRequest.PollId, voteRequest.UserId, votes)
+	if err != nil {
+		writeMeetingPollError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, poll)
+}
+
+func (h *meetingPollHandler) Finalize(c *gin.Context) {
+	type FinalizeRequest struct {
+		PollId   uuid.UUID `json:"pollId"`
+		UserId   uuid.UUID `json:"userId"`
+		OptionId uuid.UUID `json:"optionId"`
+	}
+
+	var finalizeRequest FinalizeRequest
+
+	if err := c.ShouldBindJSON(&finalizeRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	schedule, err := h.service.FinalizeMeetingPoll(finalizeRequest.PollId, finalizeRequest.UserId, finalizeRequest.OptionId)
+	if err != nil {
+		writeMeetingPollError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+func (h *meetingPollHandler) Cancel(c *gin.Context) {
+	type CancelRequest struct {
+		PollId uuid.UUID `json:"pollId"`
+		UserId uuid.UUID `json:"userId"`
+	}
+
+	var cancelRequest CancelRequest
+
+	if err := c.ShouldBindJSON(&cancelRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := h.service.CancelMeetingPoll(cancelRequest.PollId, cancelRequest.UserId); err != nil {
+		writeMeetingPollError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Poll cancelled"})
+}
+
+func writeMeetingPollError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrMeetingPollNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Poll not found"})
+	case errors.Is(err, services.ErrMeetingPollForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrMeetingPollClosed):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidMeetingPoll):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		writeScheduleError(c, err)
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type NotificationHandler interface {
+	GetAllByUser(c *gin.Context)
+	MarkAsRead(c *gin.Context)
+}
+
+type notificationHandler struct {
+	service services.NotificationService
+}
+
+func NewNotificationHandler() NotificationHandler {
+	return &notificationHandler{
+		service: services.NewNotificationService(),
+	}
+}
+
+func (h *notificationHandler) GetAllByUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	notifications, err := h.service.GetNotificationsByUser(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+func (h *notificationHandler) MarkAsRead(c *gin.Context) {
+	type MarkAsReadRequest struct {
+		Id     uuid.UUID `json:"id"`
+		UserId uuid.UUID `json:"userId"`
+	}
+
+	var request MarkAsReadRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := h.service.MarkAsRead(request.Id, request.UserId); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification read"})
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PersonalTokenHandler interface {
+	Create(c *gin.Context)
+	GetAllByUser(c *gin.Context)
+	Revoke(c *gin.Context)
+}
+
+type personalTokenHandler struct {
+	service services.PersonalTokenService
+}
+
+func NewPersonalTokenHandler() PersonalTokenHandler {
+	return &personalTokenHandler{
+		service: services.NewPersonalTokenService(),
+	}
+}
+
+func (h *personalTokenHandler) Create(c *gin.Context) {
+	type CreatePersonalTokenRequest struct {
+		UserId uuid.UUID `json:"userId"`
+		Name   string    `json:"name"`
+	}
+
+	var request CreatePersonalTokenRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	response, err := h.service.CreatePersonalToken(request.UserId, request.Name)
+	if errors.Is(err, services.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *personalTokenHandler) GetAllByUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	tokens, err := h.service.GetPersonalTokensByUser(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (h *personalTokenHandler) Revoke(c *gin.Context) {
+	type RevokePersonalTokenRequest struct {
+		Id     uuid.UUID `json:"id"`
+		UserId uuid.UUID `json:"userId"`
+	}
+
+	var request RevokePersonalTokenRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	err := h.service.RevokePersonalToken(request.Id, request.UserId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Personal token not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Personal token revoked"})
+}
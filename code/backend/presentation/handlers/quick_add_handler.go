@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type QuickAddHandler interface {
+	Parse(c *gin.Context)
+}
+
+type quickAddHandler struct {
+	service services.QuickAddService
+}
+
+func NewQuickAddHandler() QuickAddHandler {
+	return &quickAddHandler{
+		service: services.NewQuickAddService(),
+	}
+}
+
+// Parse returns a draft schedule for the text without saving anything. Now is
+// optional and defaults to the current time in the given timezone.
+func (h *quickAddHandler) Parse(c *gin.Context) {
+	type QuickAddRequest struct {
+		UserId   uuid.UUID `json:"userId"`
+		Text     string    `json:"text"`
+		Timezone string    `json:"timezone"`
+		Now      string    `json:"now"`
+	}
+
+	var quickAddRequest QuickAddRequest
+
+	if err := c.ShouldBindJSON(&quickAddRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	loc := time.UTC
+	if quickAddRequest.Timezone != "" {
+		l, err := time.LoadLocation(quickAddRequest.Timezone)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timezone"})
+			return
+		}
+		loc = l
+	}
+
+	now := time.Now().In(loc)
+	if quickAddRequest.Now != "" {
+		t, err := time.ParseInLocation("2006-01-02T15:04:05", quickAddRequest.Now, loc)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid now"})
+			return
+		}
+		now = t
+	}
+
+	draft, err := h.service.ParseQuickAdd(quickAddRequest.UserId, quickAddRequest.Text, now)
+	if errors.Is(err, services.ErrInvalidQuickAdd) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
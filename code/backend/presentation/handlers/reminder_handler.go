@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReminderHandler interface {
+	GetScheduleReminders(c *gin.Context)
+	SetScheduleReminders(c *gin.Context)
+	GetDefaultReminders(c *gin.Context)
+	SetDefaultReminders(c *gin.Context)
+}
+
+type reminderHandler struct {
+	service services.ReminderService
+}
+
+func NewReminderHandler() ReminderHandler {
+	return &reminderHandler{
+		service: services.NewReminderService(),
+	}
+}
+
+type reminderRequest struct {
+	OffsetMinutes int    `json:"offsetMinutes"`
+	Channel       string `json:"channel"`
+}
+
+func toReminders(requests []reminderRequest) []entities.Reminder {
+	reminders := make([]entities.Reminder, 0, len(requests))
+	for _, request := range requests {
+		reminders = append(reminders, entities.Reminder{
+			OffsetMinutes: request.OffsetMinutes,
+			Channel:       request.Channel,
+		})
+	}
+
+	return reminders
+}
+
+func (h *reminderHandler) GetScheduleReminders(c *gin.Context) {
+	type GetScheduleRemindersRequest struct {
+		UserId     uuid.UUID `json:"userId"`
+		ScheduleId uuid.UUID `json:"scheduleId"`
+	}
+
+	var request GetScheduleRemindersRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	reminders, err := h.service.GetScheduleReminders(request.UserId, request.ScheduleId)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reminders)
+}
+
+func (h *reminderHandler) SetScheduleReminders(c *gin.Context) {
+	type SetScheduleRemindersRequest struct {
+		UserId     uuid.UUID         `json:"userId"`
+		ScheduleId uuid.UUID         `json:"scheduleId"`
+		Reminders  []reminderRequest `json:"reminders"`
+	}
+
+	var request SetScheduleRemindersRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	err := h.service.SetScheduleReminders(request.UserId, request.ScheduleId, toReminders(request.Reminders))
+	if errors.Is(err, services.ErrInvalidReminder) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reminders updated"})
+}
+
+func (h *reminderHandler) GetDefaultReminders(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	reminders, err := h.service.GetDefaultReminders(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reminders)
+}
+
+func (h *reminderHandler) SetDefaultReminders(c *gin.Context) {
+	type SetDefaultRemindersRequest struct {
+		UserId    uuid.UUID         `json:"userId"`
+		Reminders []reminderRequest `json:"reminders"`
+	}
+
+	var request SetDefaultRemindersRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	err := h.service.SetDefaultReminders(request.UserId, toReminders(request.Reminders))
+	if errors.Is(err, services.ErrInvalidReminder) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Default reminders updated"})
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RoomHandler interface {
+	Create(c *gin.Context)
+	Get(c *gin.Context)
+	GetAll(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	SearchAvailable(c *gin.Context)
+}
+
+type roomHandler struct {
+	service services.RoomService
+}
+
+func NewRoomHandler() RoomHandler {
+	return &roomHandler{
+		service: services.NewRoomService(),
+	}
+}
+
+func (h *roomHandler) Create(c *gin.Context) {
+	var Room entities.Room
+
+	if err := c.ShouldBindJSON(&Room); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	room, err := h.service.CreateNewRoom(Room)
+	if err != nil {
+		writeRoomError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, room)
+}
+
+func (h *roomHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid room ID"})
+		return
+	}
+
+	room, err := h.service.GetRoomByID(id)
+	if err != nil {
+		writeRoomError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+func (h *roomHandler) GetAll(c *gin.Context) {
+	rooms, err := h.service.GetAllRooms()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rooms)
+}
+
+func (h *roomHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid room ID"})
+		return
+	}
+
+	var Room entities.Room
+
+	if err := c.ShouldBindJSON(&Room); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	Room.Id = id
+	if err := h.service.UpdateRoom(Room); err != nil {
+		writeRoomError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Room updated"})
+}
+
+func (h *roomHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid room ID"})
+		return
+	}
+
+	if err := h.service.DeleteRoom(id); err != nil {
+		writeRoomError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Room deleted"})
+}
+
+func (h *roomHandler) SearchAvailable(c *gin.Context) {
+	type RoomSearchRequest struct {
+		StartTime   string   `json:"startTime"`
+		EndTime     string   `json:"endTime"`
+		MinCapacity int      `json:"minCapacity"`
+		Building    string   `json:"building"`
+		Features    []string `json:"features"`
+	}
+
+	var roomSearchRequest RoomSearchRequest
+
+	if err := c.ShouldBindJSON(&roomSearchRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	startTime, err := time.Parse("2006-01-02T15:04:05", roomSearchRequest.StartTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start time"})
+		return
+	}
+
+	endTime, err := time.Parse("2006-01-02T15:04:05", roomSearchRequest.EndTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end time"})
+		return
+	}
+
+	rooms, err := h.service.SearchAvailableRooms(entities.RoomQuery{
+		StartTime:   startTime,
+		EndTime:     endTime,
+		MinCapacity: roomSearchRequest.MinCapacity,
+		Building:    roomSearchRequest.Building,
+		Features:    roomSearchRequest.Features,
+	})
+	if err != nil {
+		writeRoomError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rooms)
+}
+
+func writeRoomError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrRoomNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Room not found"})
+	case errors.Is(err, services.ErrDuplicateRoom):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidRoom), errors.Is(err, services.ErrInvalidRoomQuery):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ScheduleCopyHandler interface {
+	Copy(c *gin.Context)
+}
+
+type scheduleCopyHandler struct {
+	service services.ScheduleCopyService
+}
+
+func NewScheduleCopyHandler() ScheduleCopyHandler {
+	return &scheduleCopyHandler{
+		service: services.NewScheduleCopyService(),
+	}
+}
+
+// Copy duplicates schedules by offsetMinutes, or into the week of targetWeek
+// ("2006-01-02", any day of that week). Without either the copies land at the
+// same times as the originals.
+func (h *scheduleCopyHandler) Copy(c *gin.Context) {
+	type CopyRequest struct {
+		UserId        uuid.UUID   `json:"userId"`
+		ScheduleIds   []uuid.UUID `json:"scheduleIds"`
+		OffsetMinutes int         `json:"offsetMinutes"`
+		TargetWeek    string      `json:"targetWeek"`
+		RecipientId   *uuid.UUID  `json:"recipientId"`
+	}
+
+	var copyRequest CopyRequest
+
+	if err := c.ShouldBindJSON(&copyRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	request := entities.ScheduleCopyRequest{
+		UserId:      copyRequest.UserId,
+		ScheduleIds: copyRequest.ScheduleIds,
+		Offset:      time.Duration(copyRequest.OffsetMinutes) * time.Minute,
+		RecipientId: copyRequest.RecipientId,
+	}
+
+	if copyRequest.TargetWeek != "" {
+		targetWeek, err := time.Parse("2006-01-02", copyRequest.TargetWeek)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target week"})
+			return
+		}
+		request.TargetWeek = &targetWeek
+	}
+
+	result, err := h.service.CopySchedules(request)
+	if errors.Is(err, services.ErrInvalidScheduleCopy) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		writeScheduleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"time"
@@ -27,21 +28,26 @@ type ScheduleHandler interface {
 	Get(c *gin.Context)
 	GetAll(c *gin.Context)
 	Update(c *gin.Context)
+	Patch(c *gin.Context)
 	Delete(c *gin.Context)
 	AcceptSchedule(c *gin.Context)
 	RejectSchedule(c *gin.Context)
+	SetCoOrganizer(c *gin.Context)
 	GetAllScheduleRequestsByUser(c *gin.Context)
 	GetAllScheduleRequestsBySchedule(c *gin.Context)
 	GetAllAcceptedSchedulesBySchedule(c *gin.Context)
+	GetFriendSchedules(c *gin.Context)
 }
 
 type scheduleHandler struct {
-	service services.ScheduleService
+	service                 services.ScheduleService
+	academicCalendarService services.AcademicCalendarService
 }
 
 func NewScheduleHandler() ScheduleHandler {
 	return &scheduleHandler{
-		service: services.NewScheduleService(),
+		service:                 services.NewScheduleService(),
+		academicCalendarService: services.NewAcademicCalendarService(),
 	}
 }
 
@@ -50,12 +56,19 @@ func (h *scheduleHandler) Create(c *gin.Context) {
 		UserId         string          `json:"userId"`
 		StartTime      string          `json:"startTime"`
 		EndTime        string          `json:"endTime"`
+		IsAllDay       bool            `json:"isAllDay"`
 		Title          string          `json:"title"`
 		Description    string          `json:"description"`
 		Location       string          `json:"location"`
 		Category       string          `json:"category"`
+		CategoryId     *uuid.UUID      `json:"categoryId"`
+		Visibility     string          `json:"visibility"`
+		RoomId         *uuid.UUID      `json:"roomId"`
 		Participants   []entities.User `json:"participants"`
 		RecurringUntil string          `json:"recurringUntil"`
+		// RecurringUntilEndOfTerm repeats the schedule through the last day of
+		// the academic term it starts in, instead of RecurringUntil.
+		RecurringUntilEndOfTerm bool `json:"recurringUntilEndOfTerm"`
 	}
 
 	var scheduleRequest ScheduleRequest
@@ -66,48 +79,84 @@ func (h *scheduleHandler) Create(c *gin.Context) {
 		return
 	}
 
-	startTime, err := time.Parse("2006-01-02T15:04:05", scheduleRequest.StartTime)
+	startTime, err := parseScheduleTime(scheduleRequest.StartTime, scheduleRequest.IsAllDay)
 	if err != nil {
 		log.Println(err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start time"})
 		return
 	}
 
-	endTime, err := time.Parse("2006-01-02T15:04:05", scheduleRequest.EndTime)
+	endTime, err := parseScheduleTime(scheduleRequest.EndTime, scheduleRequest.IsAllDay)
 	if err != nil {
 		log.Println(err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end time"})
 		return
 	}
+	if scheduleRequest.IsAllDay && len(scheduleRequest.EndTime) == len("2006-01-02") {
+		// A date-only end names the last day of the event.
+		endTime = endTime.AddDate(0, 0, 1)
+	}
 
-	if scheduleRequest.RecurringUntil != "" {
-		recurringUntil, err := time.Parse("2006-01-02", scheduleRequest.RecurringUntil)
-		if err != nil {
-			log.Println(err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurring until time"})
-			return
+	if scheduleRequest.RecurringUntil != "" || scheduleRequest.RecurringUntilEndOfTerm {
+		var recurringUntil time.Time
+
+		if scheduleRequest.RecurringUntilEndOfTerm {
+			term, err := h.academicCalendarService.GetTermForDate(startTime)
+			if errors.Is(err, services.ErrAcademicTermNotFound) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Start time is not within an academic term"})
+				return
+			}
+			if err != nil {
+				log.Println(err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+				return
+			}
+			recurringUntil = term.EndDate
+		} else {
+			recurringUntil, err = time.Parse("2006-01-02", scheduleRequest.RecurringUntil)
+			if err != nil {
+				log.Println(err)
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurring until time"})
+				return
+			}
 		}
 
-		if startTime.After(recurringUntil) {
+		if !startTime.Before(recurringUntil.AddDate(0, 0, 1)) {
 			log.Println("Start time must be before recurring until date")
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Start time must be before recurring until date"})
 			return
 		}
 
+		// Occurrences on academic breaks and holidays are skipped.
+		occurrences, err := h.academicCalendarService.WeeklyOccurrences(startTime, recurringUntil)
+		if err != nil {
+			log.Println(err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+			return
+		}
+		if len(occurrences) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Every occurrence falls on an academic break or holiday"})
+			return
+		}
+
 		delta := endTime.Sub(startTime)
 
 		schedules := make([]entities.Schedule, 0)
 
-		for currTime := startTime; !currTime.After(recurringUntil); currTime = currTime.AddDate(0, 0, 7) {
+		for _, currTime := range occurrences {
 			schedule := entities.Schedule{
 				Id:          uuid.New(),
 				UserId:      uuid.MustParse(scheduleRequest.UserId),
 				StartTime:   currTime,
 				EndTime:     currTime.Add(delta),
+				IsAllDay:    scheduleRequest.IsAllDay,
 				Title:       scheduleRequest.Title,
 				Description: scheduleRequest.Description,
 				Location:    scheduleRequest.Location,
 				Category:    scheduleRequest.Category,
+				CategoryId:  scheduleRequest.CategoryId,
+				Visibility:  scheduleRequest.Visibility,
+				RoomId:      scheduleRequest.RoomId,
 			}
 
 			if len(scheduleRequest.Participants) > 0 {
@@ -135,8 +184,7 @@ func (h *scheduleHandler) Create(c *gin.Context) {
 		}
 
 		if err := h.service.BatchCreateNewSchedule(schedules); err != nil {
-			log.Println(err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			writeScheduleError(c, err)
 			return
 		}
 
@@ -149,15 +197,18 @@ func (h *scheduleHandler) Create(c *gin.Context) {
 		UserId:      uuid.MustParse(scheduleRequest.UserId),
 		StartTime:   startTime,
 		EndTime:     endTime,
+		IsAllDay:    scheduleRequest.IsAllDay,
 		Title:       scheduleRequest.Title,
 		Description: scheduleRequest.Description,
 		Location:    scheduleRequest.Location,
 		Category:    scheduleRequest.Category,
+		CategoryId:  scheduleRequest.CategoryId,
+		Visibility:  scheduleRequest.Visibility,
+		RoomId:      scheduleRequest.RoomId,
 	}
 
 	if err := h.service.CreateNewSchedule(schedule); err != nil {
-		log.Println(err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeScheduleError(c, err)
 		return
 	}
 
@@ -188,19 +239,26 @@ func (h *scheduleHandler) Create(c *gin.Context) {
 
 func (h *scheduleHandler) Get(c *gin.Context) {
 	id, _ := uuid.Parse(c.Param("id"))
+	userID, ok := actingUser(c)
+	if !ok {
+		return
+	}
 
-	Schedule, err := h.service.GetScheduleByID(id)
+	Schedule, err := h.service.GetScheduleForUser(id, userID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Schedule not found"})
+		writeScheduleError(c, err)
 		return
 	}
 
+	c.Header("ETag", versionETag(Schedule.Version))
 	c.JSON(http.StatusOK, Schedule)
 }
 
+// GetAll returns every schedule of the requested user to that user. Anyone else
+// gets the view GetFriendSchedules allows them.
 func (h *scheduleHandler) GetAll(c *gin.Context) {
 	type UserID struct {
-		UserID string `json:"userID"`
+		UserID uuid.UUID `json:"userID"`
 	}
 
 	var userID UserID
@@ -211,7 +269,12 @@ func (h *scheduleHandler) GetAll(c *gin.Context) {
 		return
 	}
 
-	Schedules, err := h.service.GetAllSchedules(userID.UserID)
+	viewerID, ok := actingUser(c)
+	if !ok {
+		return
+	}
+
+	Schedules, err := h.service.GetFriendSchedules(viewerID, userID.UserID)
 	if err != nil {
 		log.Println(err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
@@ -223,6 +286,11 @@ func (h *scheduleHandler) GetAll(c *gin.Context) {
 
 func (h *scheduleHandler) Update(c *gin.Context) {
 	id := c.Param("id")
+	userID, ok := actingUser(c)
+	if !ok {
+		return
+	}
+
 	var Schedule entities.Schedule
 
 	if err := c.ShouldBindJSON(&Schedule); err != nil {
@@ -231,28 +299,106 @@ func (h *scheduleHandler) Update(c *gin.Context) {
 	}
 
 	Schedule.Id = uuid.MustParse(id)
-	if err := h.service.UpdateSchedule(Schedule); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	version, conditional, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if conditional {
+		Schedule.Version = version
+	}
+
+	err = h.service.UpdateSchedule(userID, Schedule)
+	if errors.Is(err, services.ErrVersionConflict) {
+		current, err := h.service.GetScheduleByID(Schedule.Id)
+		if err != nil {
+			writeScheduleError(c, services.ErrScheduleNotFound)
+			return
+		}
+
+		writeVersionConflict(c, conditional, current.Version, current)
+		return
+	}
+	if err != nil {
+		writeScheduleError(c, err)
 		return
 	}
 
+	if current, err := h.service.GetScheduleByID(Schedule.Id); err == nil {
+		c.Header("ETag", versionETag(current.Version))
+	}
 	c.JSON(http.StatusOK, gin.H{"message": "Schedule updated"})
 }
 
+// Patch applies a JSON Merge Patch; members left out keep their stored values.
+func (h *scheduleHandler) Patch(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+	userID, ok := actingUser(c)
+	if !ok {
+		return
+	}
+
+	var patch services.MergePatch
+
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	version, conditional, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	Schedule, err := h.service.PatchSchedule(id, userID, patch, version)
+	var fieldErrors services.FieldErrors
+	if errors.As(err, &fieldErrors) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid patch", "fields": fieldErrors})
+		return
+	}
+	if errors.Is(err, services.ErrVersionConflict) {
+		current, err := h.service.GetScheduleByID(id)
+		if err != nil {
+			writeScheduleError(c, services.ErrScheduleNotFound)
+			return
+		}
+
+		writeVersionConflict(c, conditional, current.Version, current)
+		return
+	}
+	if err != nil {
+		writeScheduleError(c, err)
+		return
+	}
+
+	c.Header("ETag", versionETag(Schedule.Version))
+	c.JSON(http.StatusOK, Schedule)
+}
+
 func (h *scheduleHandler) Delete(c *gin.Context) {
 	id, _ := uuid.Parse(c.Param("id"))
+	userID, ok := actingUser(c)
+	if !ok {
+		return
+	}
 
-	if err := h.service.DeleteSchedule(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.service.DeleteSchedule(id, userID); err != nil {
+		writeScheduleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Schedule deleted"})
+	c.JSON(http.StatusOK, gin.H{"message": "Schedule moved to trash"})
 }
 
 func (h *scheduleHandler) AcceptSchedule(c *gin.Context) {
 	type AcceptScheduleRequest struct {
-		Id uuid.UUID `json:"id"`
+		Id     uuid.UUID `json:"id"`
+		UserId uuid.UUID `json:"userId"`
 	}
 
 	var scheduleRequest AcceptScheduleRequest
@@ -263,8 +409,8 @@ func (h *scheduleHandler) AcceptSchedule(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.AcceptSchedule(scheduleRequest.Id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.service.AcceptSchedule(scheduleRequest.Id, scheduleRequest.UserId); err != nil {
+		writeScheduleError(c, err)
 		return
 	}
 
@@ -273,7 +419,8 @@ func (h *scheduleHandler) AcceptSchedule(c *gin.Context) {
 
 func (h *scheduleHandler) RejectSchedule(c *gin.Context) {
 	type RejectScheduleRequest struct {
-		Id uuid.UUID `json:"id"`
+		Id     uuid.UUID `json:"id"`
+		UserId uuid.UUID `json:"userId"`
 	}
 
 	var scheduleRequest RejectScheduleRequest
@@ -284,14 +431,38 @@ func (h *scheduleHandler) RejectSchedule(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.RejectSchedule(scheduleRequest.Id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.service.RejectSchedule(scheduleRequest.Id, scheduleRequest.UserId); err != nil {
+		writeScheduleError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Schedule rejected"})
 }
 
+func (h *scheduleHandler) SetCoOrganizer(c *gin.Context) {
+	type SetCoOrganizerRequest struct {
+		ScheduleId        uuid.UUID `json:"scheduleId"`
+		UserId            uuid.UUID `json:"userId"`
+		ParticipantUserId uuid.UUID `json:"participantUserId"`
+		CoOrganizer       bool      `json:"coOrganizer"`
+	}
+
+	var coOrganizerRequest SetCoOrganizerRequest
+
+	if err := c.ShouldBindJSON(&coOrganizerRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := h.service.SetCoOrganizer(coOrganizerRequest.ScheduleId, coOrganizerRequest.UserId, coOrganizerRequest.ParticipantUserId, coOrganizerRequest.CoOrganizer); err != nil {
+		writeScheduleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Participant role updated"})
+}
+
 func (h *scheduleHandler) GetAllScheduleRequestsByUser(c *gin.Context) {
 	userID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -315,9 +486,14 @@ func (h *scheduleHandler) GetAllScheduleRequestsBySchedule(c *gin.Context) {
 		return
 	}
 
-	participants, err := h.service.GetAllScheduleRequestsBySchedule(scheduleID)
+	userID, ok := actingUser(c)
+	if !ok {
+		return
+	}
+
+	participants, err := h.service.GetAllScheduleRequestsBySchedule(scheduleID, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeScheduleError(c, err)
 		return
 	}
 
@@ -331,11 +507,82 @@ func (h *scheduleHandler) GetAllAcceptedSchedulesBySchedule(c *gin.Context) {
 		return
 	}
 
-	participants, err := h.service.GetAllAcceptedSchedulesBySchedule(scheduleID)
+	userID, ok := actingUser(c)
+	if !ok {
+		return
+	}
+
+	participants, err := h.service.GetAllAcceptedSchedulesBySchedule(scheduleID, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeScheduleError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, participants)
 }
+
+func (h *scheduleHandler) GetFriendSchedules(c *gin.Context) {
+	type FriendSchedulesRequest struct {
+		ViewerId uuid.UUID `json:"viewerId"`
+		UserId   uuid.UUID `json:"userId"`
+	}
+
+	var friendSchedulesRequest FriendSchedulesRequest
+
+	if err := c.ShouldBindJSON(&friendSchedulesRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	schedules, err := h.service.GetFriendSchedules(friendSchedulesRequest.ViewerId, friendSchedulesRequest.UserId)
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+func writeScheduleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrScheduleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Schedule not found"})
+	case errors.Is(err, services.ErrScheduleForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrParticipantNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Participant not found"})
+	case errors.Is(err, services.ErrInvalidVisibility):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrRoomNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Room not found"})
+	case errors.Is(err, services.ErrRoomUnavailable):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// actingUser reads the user making the request from the userId query parameter.
+func actingUser(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+// parseScheduleTime reads "2006-01-02T15:04:05", or a plain "2006-01-02" for all-day schedules.
+func parseScheduleTime(value string, allDay bool) (time.Time, error) {
+	if allDay {
+		if t, err := time.Parse("2006-01-02", value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Parse("2006-01-02T15:04:05", value)
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ScheduleProposalHandler interface {
+	Propose(c *gin.Context)
+	GetAllBySchedule(c *gin.Context)
+	Accept(c *gin.Context)
+	Decline(c *gin.Context)
+}
+
+type scheduleProposalHandler struct {
+	service services.ScheduleProposalService
+}
+
+func NewScheduleProposalHandler() ScheduleProposalHandler {
+	return &scheduleProposalHandler{
+		service: services.NewScheduleProposalService(),
+	}
+}
+
+// Propose takes the participant row id, like accept-schedule. For all-day
+// schedules the times may be plain dates, with endTime naming the last day.
+func (h *scheduleProposalHandler) Propose(c *gin.Context) {
+	type ProposeRequest struct {
+		Id        uuid.UUID `json:"id"`
+		UserId    uuid.UUID `json:"userId"`
+		StartTime string    `json:"startTime"`
+		EndTime   string    `json:"endTime"`
+		Note      string    `json:"note"`
+	}
+
+	var proposeRequest ProposeRequest
+
+	if err := c.ShouldBindJSON(&proposeRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	startTime, err := parseScheduleTime(proposeRequest.StartTime, true)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start time"})
+		return
+	}
+
+	endTime, err := parseScheduleTime(proposeRequest.EndTime, true)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end time"})
+		return
+	}
+	if len(proposeRequest.EndTime) == len("2006-01-02") {
+		endTime = endTime.AddDate(0, 0, 1)
+	}
+
+	proposal, err := h.service.ProposeTime(proposeRequest.Id, proposeRequest.UserId, startTime, endTime, proposeRequest.Note)
+	if err != nil {
+		writeProposalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, proposal)
+}
+
+func (h *scheduleProposalHandler) GetAllBySchedule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	userID, ok := actingUser(c)
+	if !ok {
+		return
+	}
+
+	proposals, err := h.service.GetProposalsBySchedule(id, userID)
+	if err != nil {
+		writeProposalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, proposals)
+}
+
+func (h *scheduleProposalHandler) Accept(c *gin.Context) {
+	type AcceptProposalRequest struct {
+		ProposalId uuid.UUID `json:"proposalId"`
+		UserId     uuid.UUID `json:"userId"`
+	}
+
+	var proposalRequest AcceptProposalRequest
+
+	if err := c.ShouldBindJSON(&proposalRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	schedule, err := h.service.AcceptProposal(proposalRequest.ProposalId, proposalRequest.UserId)
+	if err != nil {
+		writeProposalError(c, err)
+		return
+	}
+
+	c.Header("ETag", versionETag(schedule.Version))
+	c.JSON(http.StatusOK, schedule)
+}
+
+func (h *scheduleProposalHandler) Decline(c *gin.Context) {
+	type DeclineProposalRequest struct {
+		ProposalId uuid.UUID `json:"proposalId"`
+		UserId     uuid.UUID `json:"userId"`
+	}
+
+	var proposalRequest DeclineProposalRequest
+
+	if err := c.ShouldBindJSON(&proposalRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := h.service.DeclineProposal(proposalRequest.ProposalId, proposalRequest.UserId); err != nil {
+		writeProposalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Proposal declined"})
+}
+
+func writeProposalError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProposalNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Proposal not found"})
+	case errors.Is(err, services.ErrProposalClosed), errors.Is(err, services.ErrVersionConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidProposal):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		writeScheduleError(c, err)
+	}
+}
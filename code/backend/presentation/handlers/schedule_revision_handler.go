@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ScheduleRevisionHandler interface {
+	GetAllBySchedule(c *gin.Context)
+	Revert(c *gin.Context)
+}
+
+type scheduleRevisionHandler struct {
+	service         services.ScheduleRevisionService
+	scheduleService services.ScheduleService
+}
+
+func NewScheduleRevisionHandler() ScheduleRevisionHandler {
+	return &scheduleRevisionHandler{
+		service:         services.NewScheduleRevisionService(),
+		scheduleService: services.NewScheduleService(),
+	}
+}
+
+func (h *scheduleRevisionHandler) GetAllBySchedule(c *gin.Context) {
+	scheduleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	revisions, err := h.service.GetRevisionsBySchedule(scheduleID, userID)
+	if err != nil {
+		writeScheduleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+func (h *scheduleRevisionHandler) Revert(c *gin.Context) {
+	type RevertScheduleRequest struct {
+		RevisionId uuid.UUID `json:"revisionId"`
+		UserId     uuid.UUID `json:"userId"`
+	}
+
+	var revertRequest RevertScheduleRequest
+
+	if err := c.ShouldBindJSON(&revertRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	schedule, err := h.scheduleService.RevertSchedule(revertRequest.RevisionId, revertRequest.UserId)
+	if errors.Is(err, services.ErrRevisionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Revision not found"})
+		return
+	}
+	if err != nil {
+		writeScheduleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
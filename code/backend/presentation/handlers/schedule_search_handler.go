@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ScheduleSearchHandler interface {
+	Search(c *gin.Context)
+}
+
+type scheduleSearchHandler struct {
+	service services.ScheduleSearchService
+}
+
+func NewScheduleSearchHandler() ScheduleSearchHandler {
+	return &scheduleSearchHandler{
+		service: services.NewScheduleSearchService(),
+	}
+}
+
+func (h *scheduleSearchHandler) Search(c *gin.Context) {
+	type SearchRequest struct {
+		UserId uuid.UUID `json:"userId"`
+		Query  string    `json:"query"`
+		Limit  int       `json:"limit"`
+	}
+
+	var searchRequest SearchRequest
+
+	if err := c.ShouldBindJSON(&searchRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	result, err := h.service.SearchSchedules(entities.ScheduleSearchQuery{
+		UserId: searchRequest.UserId,
+		Query:  searchRequest.Query,
+		Limit:  searchRequest.Limit,
+	})
+	if errors.Is(err, services.ErrInvalidSearchQuery) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ScheduleTemplateHandler interface {
+	Create(c *gin.Context)
+	Get(c *gin.Context)
+	GetAllByUser(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	Instantiate(c *gin.Context)
+}
+
+type scheduleTemplateHandler struct {
+	service services.ScheduleTemplateService
+}
+
+func NewScheduleTemplateHandler() ScheduleTemplateHandler {
+	return &scheduleTemplateHandler{
+		service: services.NewScheduleTemplateService(),
+	}
+}
+
+func (h *scheduleTemplateHandler) Create(c *gin.Context) {
+	var Template entities.ScheduleTemplate
+
+	if err := c.ShouldBindJSON(&Template); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	template, err := h.service.CreateNewTemplate(Template)
+	if err != nil {
+		writeTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+func (h *scheduleTemplateHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	template, err := h.service.GetTemplateByID(id)
+	if err != nil {
+		writeTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+func (h *scheduleTemplateHandler) GetAllByUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	templates, err := h.service.GetTemplatesByUser(userID)
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+func (h *scheduleTemplateHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	var Template entities.ScheduleTemplate
+
+	if err := c.ShouldBindJSON(&Template); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	Template.Id = id
+	if err := h.service.UpdateTemplate(Template); err != nil {
+		writeTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Template updated"})
+}
+
+func (h *scheduleTemplateHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.service.DeleteTemplate(id, userID); err != nil {
+		writeTemplateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Template deleted"})
+}
+
+func (h *scheduleTemplateHandler) Instantiate(c *gin.Context) {
+	type InstantiateTemplateRequest struct {
+		TemplateId uuid.UUID `json:"templateId"`
+		UserId     uuid.UUID `json:"userId"`
+		StartTime  string    `json:"startTime"`
+	}
+
+	var instantiateRequest InstantiateTemplateRequest
+
+	if err := c.ShouldBindJSON(&instantiateRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	startTime, err := time.Parse("2006-01-02T15:04:05", instantiateRequest.StartTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start time"})
+		return
+	}
+
+	schedule, err := h.service.InstantiateTemplate(instantiateRequest.TemplateId, instantiateRequest.UserId, startTime)
+	if errors.Is(err, services.ErrTemplateNotFound) || errors.Is(err, services.ErrInvalidTemplate) {
+		writeTemplateError(c, err)
+		return
+	}
+	if err != nil {
+		writeScheduleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+func writeTemplateError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrTemplateNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Template not found"})
+	case errors.Is(err, services.ErrInvalidTemplate):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/domain/entities"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TaskHandler interface {
+	Create(c *gin.Context)
+	Get(c *gin.Context)
+	GetAll(c *gin.Context)
+	Update(c *gin.Context)
+	SetCompleted(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type taskHandler struct {
+	service services.TaskService
+}
+
+func NewTaskHandler() TaskHandler {
+	return &taskHandler{
+		service: services.NewTaskService(),
+	}
+}
+
+type taskRequest struct {
+	UserId           uuid.UUID  `json:"userId"`
+	Title            string     `json:"title"`
+	Description      string     `json:"description"`
+	DueDate          string     `json:"dueDate"`
+	Priority         string     `json:"priority"`
+	EstimatedMinutes int        `json:"estimatedMinutes"`
+	Course           string     `json:"course"`
+	ScheduleId       *uuid.UUID `json:"scheduleId"`
+}
+
+func (r taskRequest) toTask() (entities.Task, error) {
+	dueDate, err := time.Parse("2006-01-02T15:04:05", r.DueDate)
+	if err != nil {
+		return entities.Task{}, err
+	}
+
+	return entities.Task{
+		UserId:           r.UserId,
+		Title:            r.Title,
+		Description:      r.Description,
+		DueDate:          dueDate,
+		Priority:         r.Priority,
+		EstimatedMinutes: r.EstimatedMinutes,
+		Course:           r.Course,
+		ScheduleId:       r.ScheduleId,
+	}, nil
+}
+
+func (h *taskHandler) Create(c *gin.Context) {
+	var request taskRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	Task, err := request.toTask()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due date"})
+		return
+	}
+
+	task, err := h.service.CreateNewTask(Task)
+	if err != nil {
+		writeTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
+func (h *taskHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	task, err := h.service.GetTaskByID(id)
+	if err != nil {
+		writeTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// GetAll filters by status ("open" or "completed"), due date range, priority, course and schedule.
+func (h *taskHandler) GetAll(c *gin.Context) {
+	type TaskQueryRequest struct {
+		UserId     uuid.UUID  `json:"userId"`
+		Status     string     `json:"status"`
+		DueFrom    string     `json:"dueFrom"`
+		DueTo      string     `json:"dueTo"`
+		Priority   string     `json:"priority"`
+		Course     string     `json:"course"`
+		ScheduleId *uuid.UUID `json:"scheduleId"`
+	}
+
+	var queryRequest TaskQueryRequest
+
+	if err := c.ShouldBindJSON(&queryRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	query := entities.TaskQuery{
+		UserId:     queryRequest.UserId,
+		Priority:   queryRequest.Priority,
+		Course:     queryRequest.Course,
+		ScheduleId: queryRequest.ScheduleId,
+	}
+
+	switch queryRequest.Status {
+	case "":
+	case "open", "completed":
+		completed := queryRequest.Status == "completed"
+		query.Completed = &completed
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	if queryRequest.DueFrom != "" {
+		dueFrom, err := time.Parse("2006-01-02", queryRequest.DueFrom)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due from date"})
+			return
+		}
+		query.DueFrom = &dueFrom
+	}
+
+	if queryRequest.DueTo != "" {
+		dueTo, err := time.Parse("2006-01-02", queryRequest.DueTo)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due to date"})
+			return
+		}
+		// The end date is inclusive.
+		dueTo = dueTo.AddDate(0, 0, 1)
+		query.DueTo = &dueTo
+	}
+
+	tasks, err := h.service.GetTasks(query)
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+func (h *taskHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	var request taskRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	Task, err := request.toTask()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due date"})
+		return
+	}
+
+	Task.Id = id
+	if err := h.service.UpdateTask(Task); err != nil {
+		writeTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task updated"})
+}
+
+func (h *taskHandler) SetCompleted(c *gin.Context) {
+	type CompleteTaskRequest struct {
+		Id        uuid.UUID `json:"id"`
+		UserId    uuid.UUID `json:"userId"`
+		Completed bool      `json:"completed"`
+	}
+
+	var completeRequest CompleteTaskRequest
+
+	if err := c.ShouldBindJSON(&completeRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := h.service.SetTaskCompleted(completeRequest.Id, completeRequest.UserId, completeRequest.Completed); err != nil {
+		writeTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task updated"})
+}
+
+func (h *taskHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Query("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.service.DeleteTask(id, userID); err != nil {
+		writeTaskError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task deleted"})
+}
+
+func writeTaskError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+	case errors.Is(err, services.ErrInvalidTask):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
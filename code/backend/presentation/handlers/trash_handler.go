@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/WillyWinata/WebDevelopment-Personal/backend/application/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TrashHandler interface {
+	GetAllByUser(c *gin.Context)
+	Restore(c *gin.Context)
+}
+
+type trashHandler struct {
+	service services.TrashService
+}
+
+func NewTrashHandler() TrashHandler {
+	return &trashHandler{
+		service: services.NewTrashService(),
+	}
+}
+
+func (h *trashHandler) GetAllByUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	schedules, err := h.service.GetTrash(userID)
+	if err != nil {
+		log.Println(err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+func (h *trashHandler) Restore(c *gin.Context) {
+	type RestoreScheduleRequest struct {
+		Id     uuid.UUID `json:"id"`
+		UserId uuid.UUID `json:"userId"`
+	}
+
+	var restoreRequest RestoreScheduleRequest
+
+	if err := c.ShouldBindJSON(&restoreRequest); err != nil {
+		log.Println(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	if err := h.service.RestoreSchedule(restoreRequest.Id, restoreRequest.UserId); err != nil {
+		writeScheduleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Schedule restored"})
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
@@ -27,6 +28,7 @@ type UserHandler interface {
 	Login(c *gin.Context)
 	GetAll(c *gin.Context)
 	Update(c *gin.Context)
+	Patch(c *gin.Context)
 	Delete(c *gin.Context)
 	GetUserFollowResponse(c *gin.Context)
 }
@@ -48,7 +50,6 @@ func (h *userHandler) Create(c *gin.Context) {
 		StudentId      string `json:"studentId"`
 		Email          string `json:"email"`
 		Password       string `json:"password"`
-		Role           string `json:"role"`
 		Major          string `json:"major"`
 		ProfilePicture string `json:"profilePicture"`
 		IsActive       bool   `json:"isActive"`
@@ -64,7 +65,6 @@ func (h *userHandler) Create(c *gin.Context) {
 		StudentId:      registerRequest.StudentId,
 		Email:          registerRequest.Email,
 		Password:       registerRequest.Password,
-		Role:           registerRequest.Role,
 		Major:          registerRequest.Major,
 		ProfilePicture: registerRequest.ProfilePicture,
 		IsActive:       registerRequest.IsActive,
@@ -106,18 +106,8 @@ func (h *userHandler) Get(c *gin.Context) {
 		return
 	}
 
-	userResponse := &entities.UserFrontendResponse{
-		Id:             user.Id.String(),
-		Name:           user.Name,
-		StudentId:      user.StudentId,
-		Role:           user.Role,
-		Major:          user.Major,
-		ProfilePicture: user.ProfilePicture,
-		IsActive:       user.IsActive,
-		Email:          user.Email, // Tambahkan field Email
-	}
-
-	c.JSON(http.StatusOK, userResponse)
+	c.Header("ETag", versionETag(user.Version))
+	c.JSON(http.StatusOK, newUserFrontendResponse(user))
 }
 
 func (h *userHandler) GetAll(c *gin.Context) {
@@ -140,14 +130,106 @@ func (h *userHandler) Update(c *gin.Context) {
 	}
 
 	User.Id = uuid.MustParse(id)
-	if err := h.service.UpdateUser(User); err != nil {
+	version, conditional, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if conditional {
+		User.Version = version
+	}
+
+	err = h.service.UpdateUser(User)
+	if errors.Is(err, services.ErrVersionConflict) {
+		current, err := h.service.GetUserByID(id)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+
+		writeVersionConflict(c, conditional, current.Version, newUserFrontendResponse(current))
+		return
+	}
+	if errors.Is(err, services.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	if current, err := h.service.GetUserByID(id); err == nil {
+		c.Header("ETag", versionETag(current.Version))
+	}
 	c.JSON(http.StatusOK, gin.H{"message": "User updated"})
 }
 
+// Patch applies a JSON Merge Patch; members left out keep their stored values.
+func (h *userHandler) Patch(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var patch services.MergePatch
+
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	version, conditional, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.service.PatchUser(id, patch, version)
+	var fieldErrors services.FieldErrors
+	if errors.As(err, &fieldErrors) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid patch", "fields": fieldErrors})
+		return
+	}
+	if errors.Is(err, services.ErrVersionConflict) {
+		current, err := h.service.GetUserByID(id.String())
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+
+		writeVersionConflict(c, conditional, current.Version, newUserFrontendResponse(current))
+		return
+	}
+	if errors.Is(err, services.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("ETag", versionETag(user.Version))
+	c.JSON(http.StatusOK, newUserFrontendResponse(user))
+}
+
+// newUserFrontendResponse leaves out the password.
+func newUserFrontendResponse(user entities.User) *entities.UserFrontendResponse {
+	return &entities.UserFrontendResponse{
+		Id:             user.Id.String(),
+		Name:           user.Name,
+		StudentId:      user.StudentId,
+		Role:           user.Role,
+		Major:          user.Major,
+		ProfilePicture: user.ProfilePicture,
+		IsActive:       user.IsActive,
+		Email:          user.Email, // Tambahkan field Email
+		Version:        user.Version,
+	}
+}
+
 func (h *userHandler) Delete(c *gin.Context) {
 	email := c.Param("email")
 
@@ -19,6 +19,7 @@ func InitRoutes(r *gin.Engine) {
 	r.GET("/get-user/:id", userHandler.Get)
 	r.GET("/get-users", userHandler.GetAll)
 	r.PUT("/update-user/:id", userHandler.Update)
+	r.PATCH("/update-user/:id", userHandler.Patch)
 	r.DELETE("/delete-user/:email", userHandler.Delete)
 	r.GET("/get-user-follow/:id", userHandler.GetUserFollowResponse)
 
@@ -27,12 +28,128 @@ func InitRoutes(r *gin.Engine) {
 	r.GET("/get-schedule/:id", scheduleHandler.Get)
 	r.POST("/get-schedules", scheduleHandler.GetAll)
 	r.PUT("/update-schedule/:id", scheduleHandler.Update)
+	r.PATCH("/update-schedule/:id", scheduleHandler.Patch)
 	r.DELETE("/delete-schedule/:id", scheduleHandler.Delete)
 	r.GET("/get-schedules-request-by-user/:id", scheduleHandler.GetAllScheduleRequestsByUser)
 	r.GET("/get-schedules-request-by-schedule/:id", scheduleHandler.GetAllScheduleRequestsBySchedule)
 	r.GET("/get-schedules-accepted-by-user/:id", scheduleHandler.GetAllAcceptedSchedulesBySchedule)
 	r.PATCH("/accept-schedule", scheduleHandler.AcceptSchedule)
 	r.PATCH("/reject-schedule", scheduleHandler.RejectSchedule)
+	r.PATCH("/set-co-organizer", scheduleHandler.SetCoOrganizer)
+	r.POST("/get-friend-schedules", scheduleHandler.GetFriendSchedules)
+
+	bulkScheduleHandler := handlers.NewBulkScheduleHandler()
+	r.POST("/bulk-schedules", bulkScheduleHandler.Apply)
+
+	scheduleCopyHandler := handlers.NewScheduleCopyHandler()
+	r.POST("/copy-schedules", scheduleCopyHandler.Copy)
+
+	scheduleSearchHandler := handlers.NewScheduleSearchHandler()
+	r.POST("/search-schedules", scheduleSearchHandler.Search)
+
+	meetingPollHandler := handlers.NewMeetingPollHandler()
+	r.POST("/create-meeting-poll", meetingPollHandler.Create)
+	r.GET("/get-meeting-poll/:id", meetingPollHandler.Get)
+	r.GET("/get-meeting-polls/:id", meetingPollHandler.GetAllByUser)
+	r.POST("/vote-meeting-poll", meetingPollHandler.Vote)
+	r.POST("/finalize-meeting-poll", meetingPollHandler.Finalize)
+	r.POST("/cancel-meeting-poll", meetingPollHandler.Cancel)
+
+	scheduleProposalHandler := handlers.NewScheduleProposalHandler()
+	r.POST("/propose-schedule-time", scheduleProposalHandler.Propose)
+	r.GET("/get-schedule-proposals/:id", scheduleProposalHandler.GetAllBySchedule)
+	r.PATCH("/accept-schedule-proposal", scheduleProposalHandler.Accept)
+	r.PATCH("/decline-schedule-proposal", scheduleProposalHandler.Decline)
+
+	trashHandler := handlers.NewTrashHandler()
+	r.GET("/get-trash/:id", trashHandler.GetAllByUser)
+	r.PATCH("/restore-schedule", trashHandler.Restore)
+
+	scheduleRevisionHandler := handlers.NewScheduleRevisionHandler()
+	r.GET("/get-schedule-revisions/:id", scheduleRevisionHandler.GetAllBySchedule)
+	r.POST("/revert-schedule", scheduleRevisionHandler.Revert)
+
+	categoryHandler := handlers.NewCategoryHandler()
+	r.POST("/create-category", categoryHandler.Create)
+	r.GET("/get-category/:id", categoryHandler.Get)
+	r.GET("/get-categories/:id", categoryHandler.GetAllByUser)
+	r.PUT("/update-category/:id", categoryHandler.Update)
+	r.DELETE("/delete-category/:id", categoryHandler.Delete)
+
+	roomHandler := handlers.NewRoomHandler()
+	r.POST("/create-room", roomHandler.Create)
+	r.GET("/get-room/:id", roomHandler.Get)
+	r.GET("/get-rooms", roomHandler.GetAll)
+	r.PUT("/update-room/:id", roomHandler.Update)
+	r.DELETE("/delete-room/:id", roomHandler.Delete)
+	r.POST("/search-available-rooms", roomHandler.SearchAvailable)
+
+	attachmentHandler := handlers.NewAttachmentHandler()
+	r.POST("/upload-attachment/:id", attachmentHandler.Upload)
+	r.GET("/get-attachments/:id", attachmentHandler.GetAllBySchedule)
+	r.GET("/download-attachment/:id", attachmentHandler.Download)
+	r.DELETE("/delete-attachment/:id", attachmentHandler.Delete)
+
+	commentHandler := handlers.NewCommentHandler()
+	r.POST("/create-comment", commentHandler.Create)
+	r.GET("/get-comments/:id", commentHandler.GetAllBySchedule)
+	r.PUT("/update-comment/:id", commentHandler.Update)
+	r.DELETE("/delete-comment/:id", commentHandler.Delete)
+	r.PATCH("/lock-comment-thread", commentHandler.SetThreadLocked)
+
+	taskHandler := handlers.NewTaskHandler()
+	r.POST("/create-task", taskHandler.Create)
+	r.GET("/get-task/:id", taskHandler.Get)
+	r.POST("/get-tasks", taskHandler.GetAll)
+	r.PUT("/update-task/:id", taskHandler.Update)
+	r.PATCH("/complete-task", taskHandler.SetCompleted)
+	r.DELETE("/delete-task/:id", taskHandler.Delete)
+
+	scheduleTemplateHandler := handlers.NewScheduleTemplateHandler()
+	r.POST("/create-template", scheduleTemplateHandler.Create)
+	r.GET("/get-template/:id", scheduleTemplateHandler.Get)
+	r.GET("/get-templates/:id", scheduleTemplateHandler.GetAllByUser)
+	r.PUT("/update-template/:id", scheduleTemplateHandler.Update)
+	r.DELETE("/delete-template/:id", scheduleTemplateHandler.Delete)
+	r.POST("/instantiate-template", scheduleTemplateHandler.Instantiate)
+
+	calendarHandler := handlers.NewCalendarHandler()
+	r.POST("/get-calendar", calendarHandler.GetCalendar)
+	r.GET("/export-calendar.ics", calendarHandler.Export)
+
+	analyticsHandler := handlers.NewAnalyticsHandler()
+	r.POST("/get-time-analytics", analyticsHandler.GetTimeAnalytics)
+
+	quickAddHandler := handlers.NewQuickAddHandler()
+	r.POST("/parse-quick-add", quickAddHandler.Parse)
+
+	academicCalendarHandler := handlers.NewAcademicCalendarHandler()
+	r.GET("/get-academic-terms", academicCalendarHandler.GetTerms)
+	r.GET("/get-academic-term/:id", academicCalendarHandler.GetTerm)
+	r.POST("/create-academic-term", academicCalendarHandler.CreateTerm)
+	r.PUT("/update-academic-term/:id", academicCalendarHandler.UpdateTerm)
+	r.DELETE("/delete-academic-term/:id", academicCalendarHandler.DeleteTerm)
+	r.POST("/get-academic-exclusions", academicCalendarHandler.GetExclusions)
+	r.POST("/create-academic-exclusion", academicCalendarHandler.CreateExclusion)
+	r.PUT("/update-academic-exclusion/:id", academicCalendarHandler.UpdateExclusion)
+	r.DELETE("/delete-academic-exclusion/:id", academicCalendarHandler.DeleteExclusion)
+
+	freeBusyHandler := handlers.NewFreeBusyHandler()
+	r.POST("/get-common-free-time", freeBusyHandler.GetCommonFreeTime)
+
+	groupWorkHandler := handlers.NewGroupWorkHandler()
+	r.POST("/recommend-group-work-slots", groupWorkHandler.Recommend)
+	r.POST("/book-group-work-slot", groupWorkHandler.Book)
+
+	reminderHandler := handlers.NewReminderHandler()
+	r.POST("/get-schedule-reminders", reminderHandler.GetScheduleReminders)
+	r.PUT("/set-schedule-reminders", reminderHandler.SetScheduleReminders)
+	r.GET("/get-default-reminders/:id", reminderHandler.GetDefaultReminders)
+	r.PUT("/set-default-reminders", reminderHandler.SetDefaultReminders)
+
+	notificationHandler := handlers.NewNotificationHandler()
+	r.GET("/get-notifications/:id", notificationHandler.GetAllByUser)
+	r.PATCH("/read-notification", notificationHandler.MarkAsRead)
 
 	followHandler := handlers.NewFollowHandler()
 	r.POST("/get-follows-by-user", followHandler.GetFollowsByUser)
@@ -47,4 +164,16 @@ func InitRoutes(r *gin.Engine) {
 	r.PATCH("/accept-request", followRequestHandler.AcceptFollowRequest)
 	r.PATCH("/reject-request", followRequestHandler.RejectFollowRequest)
 	r.POST("/cancel-follow-request", followRequestHandler.CancelFollowRequest)
+
+	personalTokenHandler := handlers.NewPersonalTokenHandler()
+	r.POST("/create-personal-token", personalTokenHandler.Create)
+	r.GET("/get-personal-tokens/:id", personalTokenHandler.GetAllByUser)
+	r.POST("/revoke-personal-token", personalTokenHandler.Revoke)
+
+	calDAVHandler := handlers.NewCalDAVHandler()
+	r.Any("/.well-known/caldav", calDAVHandler.WellKnown)
+	calDAV := r.Group("/caldav", calDAVHandler.Authenticate)
+	for _, method := range handlers.CalDAVMethods {
+		calDAV.Handle(method, "/*path", calDAVHandler.Serve)
+	}
 }
@@ -54,7 +54,7 @@ export default function Dashboard() {
     let allSchedules: Schedule[] = [];
 
     for (const user of users) {
-      const response = await fetch("http://localhost:8888/get-schedules", {
+      const response = await fetch(`http://localhost:8888/get-schedules?userId=${userId}`, {
         method: "POST",
         headers: {
           "Content-Type": "application/json",
@@ -165,7 +165,7 @@ export default function Dashboard() {
     if (!currentUser) return;
 
     const getSchedules = async () => {
-      const response = await fetch("http://localhost:8888/get-schedules", {
+      const response = await fetch(`http://localhost:8888/get-schedules?userId=${userId}`, {
         method: "POST",
         headers: {
           "Content-Type": "application/json",
@@ -53,13 +53,13 @@ export default function EventView({
   const refreshEvents = async () => {
     try {
       const [acceptedSchedules, mySchedules, invitedSchedules] = await Promise.all([
-        fetch("http://localhost:8888/get-schedules-accepted-by-user/" + currentUser.id).then(res => res.json()),
-        fetch("http://localhost:8888/get-schedules", {
+        fetch("http://localhost:8888/get-schedules-accepted-by-user/" + currentUser.id + "?userId=" + currentUser.id).then(res => res.json()),
+        fetch("http://localhost:8888/get-schedules?userId=" + currentUser.id, {
           method: "POST",
           headers: { "Content-Type": "application/json" },
           body: JSON.stringify({ userId: currentUser.id })
         }).then(res => res.json()),
-        fetch("http://localhost:8888/get-schedules-request-by-schedule/" + currentUser.id).then(res => res.json())
+        fetch("http://localhost:8888/get-schedules-request-by-schedule/" + currentUser.id + "?userId=" + currentUser.id).then(res => res.json())
       ]);
 
       setUpcomingEvents(acceptedSchedules);
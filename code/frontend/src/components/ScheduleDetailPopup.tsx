@@ -125,7 +125,7 @@ export default function ScheduleDetailPopup({
     setError(null);
     try {
       const res = await fetch(
-        `http://localhost:8888/delete-schedule/${event.id}`,
+        `http://localhost:8888/delete-schedule/${event.id}?userId=${currentUser.id}`,
         {
           method: "DELETE",
         }
@@ -176,7 +176,7 @@ export default function ScheduleDetailPopup({
 
     try {
       const res = await fetch(
-        `http://localhost:8888/update-schedule/${event.id}`,
+        `http://localhost:8888/update-schedule/${event.id}?userId=${currentUser.id}`,
         {
           method: "PUT",
           headers: {
@@ -100,7 +100,7 @@ export default function ScheduleView({
 
   // Function to refresh schedules
   const refreshSchedules = async () => {
-    const response = await fetch("http://localhost:8888/get-schedules", {
+    const response = await fetch(`http://localhost:8888/get-schedules?userId=${currentUser.id}`, {
       method: "POST",
       headers: {
         "Content-Type": "application/json",